@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var barCmd = &cobra.Command{
+	Use:   "bar",
+	Short: "Print next prayer in status bar format",
+	Long: `Print next prayer in native format of a status bar, to run from its config:
+
+  waybar    json with text, tooltip of the day table, class and percentage.
+            Classes are next prayer name, and "imminent" or "now":
+              "custom/prayers": {
+                "exec": "prayers bar --mode waybar",
+                "return-type": "json",
+                "interval": 30
+              }
+  i3blocks  full text, short text and color lines
+  polybar   %{F#color} markup, for custom/script modules
+  tmux      #[fg=#color] markup:
+              set -g status-right '#(prayers bar --mode tmux)'
+
+Text is a Go template, with same fields and helpers as next command`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectOutputFormat(cmd); err != nil {
+			return err
+		}
+		options, err := getBarOptions(cmd)
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		activePrayerTracking, err := createPrayerTimesRepo(hijriOptions).GetActivePrayerTracking(time.Now())
+		if err != nil {
+			return err
+		}
+		output, err := ui.FormatBar(activePrayerTracking, options)
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// getBarOptions reads bar flags
+func getBarOptions(cmd *cobra.Command) (ui.BarOptions, error) {
+	modeStr, err := cmd.Flags().GetString("mode")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	mode, err := ui.ParseBarMode(modeStr)
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	imminent, err := cmd.Flags().GetDuration("imminent")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	now, err := cmd.Flags().GetDuration("now")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	imminentColor, err := cmd.Flags().GetString("imminent-color")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+	nowColor, err := cmd.Flags().GetString("now-color")
+	if err != nil {
+		return ui.BarOptions{}, err
+	}
+
+	return ui.BarOptions{
+		Mode:          mode,
+		Format:        format,
+		Imminent:      imminent,
+		Now:           now,
+		ImminentColor: imminentColor,
+		NowColor:      nowColor,
+	}, nil
+}
+
+func init() {
+	barCmd.Flags().String("mode", string(ui.BarWaybar), "Bar format (waybar|i3blocks|polybar|tmux)")
+	barCmd.Flags().String("format", ui.DefaultBarFormat, "Go template of bar text, see help of next command")
+	barCmd.Flags().Duration("imminent", 15*time.Minute, "Time before next prayer it is imminent")
+	barCmd.Flags().Duration("now", 10*time.Minute, "Time after a prayer starts it is still now")
+	barCmd.Flags().String("imminent-color", "#ffb86c", "Color of imminent text, for i3blocks, polybar and tmux")
+	barCmd.Flags().String("now-color", "#50fa7b", "Color of text when prayer is now, for i3blocks, polybar and tmux")
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/daemon"
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run in background notifying, running hooks and playing adhan at prayer times",
+	Long: `Run in foreground sending a desktop notification at each prayer time, and at
+lead times before it. Meant to be started by a user service, e.g. systemd.
+
+Config is read from config.json next to year files, and reloaded when it or
+year data changes:
+
+  {
+    "notifications": {
+      "prayers": ["fajr", "dhuhr", "asr", "maghrib", "isha"],
+      "before": ["15m", "5m"],
+      "reminders": [
+        {"prayer": "asr", "before": "15m"},
+        {"prayer": "asr", "before": "5m"},
+        {"prayer": "maghrib"},
+        {"prayer": "sunrise", "before": "30m",
+         "escalate": {"after": "20m", "urgency": "critical", "persistent": true}}
+      ]
+    }
+  }
+
+Reminders, if set, replace prayers and before, notifying only at times they
+name. Sunrise is notified only through reminders. A reminder with escalate notifies again,
+critical by default, if its prayer is not logged as prayed in tui after given
+time, and not before prayer time. Prayer of sunrise reminders is fajr.
+
+Hooks run shell commands at prayer events, at:prayer, before:prayer:duration
+or after:prayer:duration:
+
+  {
+    "hooks": {
+      "timeout": "30s",
+      "concurrency": 4,
+      "commands": [
+        {"on": "before:asr:10m", "run": "playerctl pause"},
+        {"on": "at:maghrib", "run": "loginctl lock-session"},
+        {"on": "after:isha:5m", "run": "./post-to-chat.sh", "timeout": "1m"}
+      ]
+    }
+  }
+
+Commands get PRAYER_NAME, PRAYER_TIME, PRAYER_EVENT and HIJRI_DATE env vars.
+Their exit status and output are appended to hooks.log next to config, or to
+"log" file if set.
+
+Adhan plays at prayer times, through first installed of mpv, paplay and
+ffplay unless player is set. It is not played in mute ranges, nor while
+another adhan plays. Stop it with Enter, or with prayers stop:
+
+  {
+    "adhan": {
+      "file": "~/audio/adhan.mp3",
+      "fajrFile": "~/audio/adhan-fajr.mp3",
+      "player": ["mpv", "--no-video", "--volume=70", "{file}"],
+      "prayers": {"dhuhr": false},
+      "mute": [{"from": "09:00", "to": "17:00", "days": ["mon", "tue", "wed", "thu"]}]
+    }
+  }
+
+Webhooks post JSON of prayer, time, location, hijri, event and dayEvent
+(en and ar name of day's event, if any) to URLs at events, as in hooks:
+
+  {
+    "webhooks": {
+      "location": "Beirut",
+      "missed": "drop",
+      "endpoints": [
+        {"url": "https://bot.example.com/prayers", "secret": "s3cret", "on": ["at:maghrib", "before:asr:10m"]}
+      ]
+    }
+  }
+
+Posts with a secret have X-Prayers-Signature header, sha256= and hex
+HMAC-SHA256 of X-Prayers-Timestamp header, a dot and body. Failed posts are
+retried with exponential backoff, up to "attempts" (8) times within "maxAge"
+(24h) of event, and are kept in webhooks-outbox.json next to config until
+then. X-Prayers-Delivery header is same for each retry. Events missed while
+machine was suspended are dropped, or posted late with "missed": "deliver".
+Events that passed while daemon was not running are not posted at all.
+
+Other events missed while machine was suspended are skipped. Logs go to stderr`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		configPath, err := config.Path()
+		if err != nil {
+			return err
+		}
+		logger := log.New(os.Stderr, "", log.LstdFlags)
+
+		notifier, err := daemon.NewNotifier()
+		if err != nil {
+			return err
+		}
+
+		pidFile, err := adhanPIDFile()
+		if err != nil {
+			return err
+		}
+		// left by a daemon that did not exit cleanly, pid may be reused by now
+		os.Remove(pidFile)
+
+		outbox, err := webhookOutboxFile()
+		if err != nil {
+			return err
+		}
+
+		hooks := &daemon.HookHandler{Log: logger}
+		adhan := &daemon.AdhanHandler{PIDFile: pidFile, Log: logger}
+		webhooks := &daemon.WebhookHandler{Outbox: outbox, Log: logger}
+		scheduler := &daemon.Scheduler{
+			Load: func() (domain.PrayerTimesRepo, config.Config, error) {
+				cfg, err := config.Load(configPath)
+				return createPrayerTimesRepo(hijriOptions), cfg, err
+			},
+			Watch: func(now time.Time) []string {
+				return daemonWatchedFiles(configPath, now)
+			},
+			Handlers: eventHandlers(notifier, hooks, adhan, webhooks, logger),
+			Now:      time.Now,
+			After:    time.After,
+			MaxSleep: time.Minute,
+			Grace:    2 * time.Minute,
+			Log:      logger,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			go stopAdhanOnEnter(adhan, logger)
+		}
+
+		webhooksDone := make(chan struct{})
+		go func() {
+			webhooks.Run(ctx)
+			close(webhooksDone)
+		}()
+
+		logger.Printf("started, config %v", configPath)
+		err = scheduler.Run(ctx)
+		adhan.Stop()
+		adhan.Wait()
+		hooks.Wait()
+		<-webhooksDone
+		return err
+	},
+}
+
+// stopAdhanOnEnter stops adhan each time Enter is pressed in terminal
+// daemon runs in
+func stopAdhanOnEnter(adhan *daemon.AdhanHandler, logger *log.Logger) {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if adhan.Stop() {
+			logger.Printf("adhan stopped by keypress")
+		}
+	}
+}
+
+// eventHandlers returns handlers of prayer events, same for daemon, for
+// installed schedule and for events it fires
+func eventHandlers(notifier daemon.Notifier, hooks *daemon.HookHandler, adhan *daemon.AdhanHandler, webhooks *daemon.WebhookHandler, logger *log.Logger) []daemon.Handler {
+	return []daemon.Handler{
+		&daemon.NotificationHandler{Notifier: notifier, PrayerLog: storage.DefaultPrayerLogStorage(), Log: logger},
+		hooks,
+		adhan,
+		webhooks,
+	}
+}
+
+// webhookOutboxFile keeps webhook payloads not delivered yet
+func webhookOutboxFile() (string, error) {
+	return storage.FilePath("webhooks-outbox.json")
+}
+
+// adhanPIDFile keeps pid and start time of adhan player while it plays
+func adhanPIDFile() (string, error) {
+	return storage.FilePath("adhan.pid")
+}
+
+// daemonWatchedFiles are config and year files daemon plans from at @now
+func daemonWatchedFiles(configPath string, now time.Time) []string {
+	files := []string{configPath}
+	for _, year := range []int{now.Year() - 1, now.Year(), now.Year() + 1} {
+		if path, err := storage.FilePath(storage.YearFileName(year)); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files
+}
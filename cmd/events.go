@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "List islamic events of the year",
+	Long: `List islamic events (Ashura, Mawlid, Laylat al-Qadr, Eids...) of the year
+set by --year, or the next N events from today when --upcoming is set`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		upcoming, err := cmd.Flags().GetInt("upcoming")
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		repo := createPrayerTimesRepo(hijriOptions)
+		now := time.Now()
+		if upcoming <= 0 {
+			events, err := repo.GetEvents(year)
+			if err != nil {
+				return err
+			}
+			return renderEvents(events, now, output)
+		}
+
+		// upcoming events may fall in next year, whose data may not be
+		// published yet, then there are no more events to show
+		events := []domain.DayEvent{}
+		for _, y := range []int{now.Year(), now.Year() + 1} {
+			if len(events) == upcoming {
+				break
+			}
+			yearEvents, err := repo.GetEvents(y)
+			if err != nil && y > now.Year() {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			for _, e := range yearEvents {
+				if domain.DaysBetween(now, e.Date) >= 0 && len(events) < upcoming {
+					events = append(events, e)
+				}
+			}
+		}
+		return renderEvents(events, now, output)
+	},
+}
+
+func renderEvents(events []domain.DayEvent, now time.Time, output ui.OutputFormat) error {
+	if output != ui.OutputTable {
+		return ui.RenderEventsJSON(events, now, output)
+	}
+	ui.RenderEvents(events, now)
+	return nil
+}
+
+func init() {
+	eventsCmd.Flags().IntP("upcoming", "u", 0, "Show only next N events starting today")
+}
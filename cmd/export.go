@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/export"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export prayer times of a date range to a file format",
+	Long: `Export prayer times of each day from --from to --to (inclusive) to stdout.
+
+Formats:
+  csv, tsv   a row per day, columns are set by --columns
+  ics        iCalendar with an event per prayer, to import into calendar apps.
+             Importing again updates events instead of duplicating them
+  html, md   self-contained timetable to publish or print, a table per month
+             with fridays and events highlighted. Right to left with
+             --hijri-lang ar. Without --from and --to, exports month of
+             --year and --month, or all of --year with --full-year
+  pdf        print ready timetable, a page per month, same range as html.
+             Title is --name, with --location under it`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectOutputFormat(cmd); err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		from, to, err := getDateRange(cmd)
+		if format == "html" || format == "md" || format == "pdf" {
+			from, to, err = getTimetableRange(cmd)
+		}
+		if err != nil {
+			return err
+		}
+		schedules := createPrayerTimesRepo(hijriOptions).GetSchedules(from, to)
+
+		switch format {
+		case "csv", "tsv":
+			options, err := getCSVOptions(cmd, format)
+			if err != nil {
+				return err
+			}
+			return export.WriteCSV(os.Stdout, schedules, options)
+		case "ics":
+			options, err := getICSOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return export.WriteICS(os.Stdout, schedules, options)
+		case "html", "md":
+			options, err := getTimetableOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if format == "html" {
+				return export.WriteHTML(os.Stdout, schedules, options)
+			}
+			return export.WriteMarkdown(os.Stdout, schedules, options)
+		case "pdf":
+			options, err := getPDFOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return export.WritePDF(os.Stdout, schedules, options)
+		}
+		return fmt.Errorf("invalid format %q, expected csv, tsv, ics, html, md or pdf", format)
+	},
+}
+
+// getCSVOptions reads csv flags, tsv @format defaults delimiter to tab
+func getCSVOptions(cmd *cobra.Command, format string) (export.CSVOptions, error) {
+	columns, err := cmd.Flags().GetStringSlice("columns")
+	if err != nil {
+		return export.CSVOptions{}, err
+	}
+	if err := export.ValidateCSVColumns(columns); err != nil {
+		return export.CSVOptions{}, err
+	}
+	timeLayout, err := cmd.Flags().GetString("time-format")
+	if err != nil {
+		return export.CSVOptions{}, err
+	}
+	delimiterStr, err := cmd.Flags().GetString("delimiter")
+	if err != nil {
+		return export.CSVOptions{}, err
+	}
+
+	delimiter := ','
+	if format == "tsv" {
+		delimiter = '\t'
+	}
+	if cmd.Flags().Changed("delimiter") {
+		if delimiterStr == `\t` {
+			delimiterStr = "\t"
+		}
+		if utf8.RuneCountInString(delimiterStr) != 1 {
+			return export.CSVOptions{}, fmt.Errorf("invalid delimiter %q, expected a single character", delimiterStr)
+		}
+		delimiter, _ = utf8.DecodeRuneInString(delimiterStr)
+	}
+
+	return export.CSVOptions{
+		Columns:    columns,
+		TimeLayout: timeLayout,
+		Delimiter:  delimiter,
+		Language:   ui.HijriLanguage,
+	}, nil
+}
+
+// getICSOptions reads ics flags
+func getICSOptions(cmd *cobra.Command) (export.ICSOptions, error) {
+	duration, err := cmd.Flags().GetDuration("duration")
+	if err != nil {
+		return export.ICSOptions{}, err
+	}
+	alarmMinutes, err := cmd.Flags().GetIntSlice("alarm")
+	if err != nil {
+		return export.ICSOptions{}, err
+	}
+	location, err := cmd.Flags().GetString("location")
+	if err != nil {
+		return export.ICSOptions{}, err
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return export.ICSOptions{}, err
+	}
+
+	alarms := []time.Duration{}
+	for _, m := range alarmMinutes {
+		if m < 0 {
+			return export.ICSOptions{}, fmt.Errorf("invalid alarm %v, expected minutes before prayer", m)
+		}
+		alarms = append(alarms, time.Duration(m)*time.Minute)
+	}
+
+	return export.ICSOptions{
+		Name:     name,
+		Duration: duration,
+		Alarms:   alarms,
+		Location: location,
+		Stamp:    time.Now(),
+	}, nil
+}
+
+// getTimetableOptions reads html and md flags
+func getTimetableOptions(cmd *cobra.Command) (export.TimetableOptions, error) {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return export.TimetableOptions{}, err
+	}
+	timeLayout, err := cmd.Flags().GetString("time-format")
+	if err != nil {
+		return export.TimetableOptions{}, err
+	}
+
+	return export.TimetableOptions{
+		Title:      name,
+		TimeLayout: timeLayout,
+		Language:   ui.HijriLanguage,
+	}, nil
+}
+
+// getPDFOptions reads pdf flags, on top of html and md ones
+func getPDFOptions(cmd *cobra.Command) (export.PDFOptions, error) {
+	timetableOptions, err := getTimetableOptions(cmd)
+	if err != nil {
+		return export.PDFOptions{}, err
+	}
+	location, err := cmd.Flags().GetString("location")
+	if err != nil {
+		return export.PDFOptions{}, err
+	}
+	pageSizeStr, err := cmd.Flags().GetString("page-size")
+	if err != nil {
+		return export.PDFOptions{}, err
+	}
+	pageSize, err := export.ParsePDFPageSize(pageSizeStr)
+	if err != nil {
+		return export.PDFOptions{}, err
+	}
+	landscape, err := cmd.Flags().GetBool("landscape")
+	if err != nil {
+		return export.PDFOptions{}, err
+	}
+
+	return export.PDFOptions{
+		TimetableOptions: timetableOptions,
+		Location:         location,
+		PageSize:         pageSize,
+		Landscape:        landscape,
+		Source:           domain.DataSource,
+		Stamp:            time.Now(),
+	}, nil
+}
+
+// getTimetableRange returns range of --from and --to if either is set,
+// otherwise month of --year and --month, or all of --year with --full-year
+func getTimetableRange(cmd *cobra.Command) (time.Time, time.Time, error) {
+	if cmd.Flags().Changed("from") || cmd.Flags().Changed("to") {
+		return getDateRange(cmd)
+	}
+
+	year, err := cmd.Flags().GetInt("year")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	month, err := cmd.Flags().GetInt("month")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	fullYear, err := cmd.Flags().GetBool("full-year")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if fullYear {
+		month = 1
+	}
+	from, err := domain.NewDate(year, month, 1, time.Now().Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if fullYear {
+		return from, from.AddDate(1, 0, -1), nil
+	}
+	return from, from.AddDate(0, 1, -1), nil
+}
+
+func init() {
+	exportCmd.Flags().StringP("format", "f", "csv", "Export format (csv|tsv|ics|html|md|pdf)")
+	exportCmd.Flags().String("from", "", "First day of range, e.g. 2025-12-25 (default today)")
+	exportCmd.Flags().String("to", "", "Last day of range, e.g. 2026-01-05 (default today)")
+	exportCmd.Flags().StringSlice("columns", export.DefaultCSVColumns, "csv columns, any of date,weekday,hijri,fajr,sunrise,dhuhr,asr,maghrib,isha,event")
+	exportCmd.Flags().String("time-format", ui.TimeLayout, "Go time layout of prayer times, e.g. 15:04")
+	exportCmd.Flags().Bool("full-year", false, "html, md and pdf export all of --year")
+	exportCmd.Flags().String("delimiter", ",", `csv delimiter, e.g. ";" or "\t", tsv uses tab unless set`)
+	exportCmd.Flags().Duration("duration", 20*time.Minute, "ics event duration of each prayer")
+	exportCmd.Flags().IntSlice("alarm", []int{}, "ics reminders, minutes before prayer, e.g. 10,5")
+	exportCmd.Flags().String("location", "", "ics event location, pdf header")
+	exportCmd.Flags().String("name", "Prayer times", "ics calendar name, html, md and pdf title")
+	exportCmd.Flags().String("page-size", string(export.PDFPageA4), "pdf page size (a4|letter)")
+	exportCmd.Flags().Bool("landscape", false, "pdf landscape pages")
+}
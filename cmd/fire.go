@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/daemon"
+	"github.com/mabd-dev/prayer-times-cli/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+var fireCmd = &cobra.Command{
+	Use:    "fire <time>",
+	Short:  "Handle prayer events of a minute, called by timers of schedule install",
+	Long:   `Send notifications, run hooks and play adhan of events at given minute, e.g. 20260320T1512, as daemon would. Events more than 2 minutes late are handled as missed`,
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		at, err := time.ParseInLocation(schedule.EventLayout, args[0], time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid event time %q, expected e.g. 20260320T1512", args[0])
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		configPath, err := config.Path()
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		logger := log.New(os.Stderr, "", log.LstdFlags)
+
+		notifier, err := daemon.NewNotifier()
+		if err != nil {
+			// hooks and adhan still run, notifications log this error
+			logger.Printf("error: %v", err)
+			notifier = unavailableNotifier{err}
+		}
+		pidFile, err := adhanPIDFile()
+		if err != nil {
+			return err
+		}
+		outbox, err := webhookOutboxFile()
+		if err != nil {
+			return err
+		}
+
+		hooks := &daemon.HookHandler{Log: logger}
+		adhan := &daemon.AdhanHandler{PIDFile: pidFile, Log: logger}
+		webhooks := &daemon.WebhookHandler{Outbox: outbox, Log: logger}
+		handlers := eventHandlers(notifier, hooks, adhan, webhooks, logger)
+		count, err := daemon.Fire(createPrayerTimesRepo(hijriOptions), cfg, handlers, at, time.Now(), 2*time.Minute)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			logger.Printf("no events at %v, run prayers schedule install again if config changed", at.Format("2006-01-02 15:04"))
+		}
+
+		// failed posts stay in outbox, retried by next fire
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		webhooks.Deliver(ctx)
+		if pending := webhooks.Pending(); pending > 0 {
+			logger.Printf("%v webhooks not delivered yet, retrying at next event", pending)
+		}
+
+		adhan.Wait()
+		hooks.Wait()
+		return nil
+	},
+}
+
+// unavailableNotifier fails each notification with error of creating notifier
+type unavailableNotifier struct {
+	err error
+}
+
+func (n unavailableNotifier) Notify(notification daemon.Notification) error {
+	return n.err
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var monthCmd = &cobra.Command{
+	Use:   "month [date]",
+	Args:  cobra.MaximumNArgs(2),
+	Short: "Get prayer times timetable of the month",
+	Long: `Get prayer times timetable of the month requested date falls in, with
+gregorian and hijri dates, sunrise, fridays and events marked`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		requestedDate, err := getRequestedDate(cmd, args, hijriOptions, true)
+		if err != nil {
+			return err
+		}
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		repo := createPrayerTimesRepo(hijriOptions)
+		schedules, err := repo.GetMonthPrayerSchedules(requestedDate.Year(), requestedDate.Month())
+		if err != nil {
+			return err
+		}
+		if output != ui.OutputTable {
+			return ui.RenderSchedulesJSON(schedules, output)
+		}
+		ui.RenderMonth(schedules, time.Now())
+		return nil
+	},
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Print only the upcoming prayer, for scripts",
+	Long: `Print only the upcoming prayer, its time and time remaining to it. Output is
+plain text without colors, and status messages go to stderr, so it is safe to
+pipe into other tools.
+
+Template fields are .Name, .Time and .Remaining, and all fields of tracking of
+active prayer, see help of root command:
+  prayers next --format '{{.Name}} {{.Remaining}}'
+  prayers next --format '{{arabic .Name}} {{fmtTime "15:04" .NextPrayerTime}}'
+
+"prayers next friday" still shows prayer times of next friday, as prayers
+does, without template and --seconds flags
+
+` + templateHelp,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, flag := range []string{"year", "month", "day", "hijri"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--%v is not supported by next, use a date argument, e.g. prayers next friday", flag)
+			}
+		}
+		// keep "prayers next <weekday>" date argument working
+		if len(args) > 0 {
+			for _, flag := range []string{"format", "template-file", "seconds"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%v can not be combined with a date argument", flag)
+				}
+			}
+			return rootCmd.RunE(cmd, append([]string{"next"}, args...))
+		}
+
+		format, err := getTemplate(cmd)
+		if err != nil {
+			return err
+		}
+		seconds, err := cmd.Flags().GetBool("seconds")
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		activePrayerTracking, err := createPrayerTimesRepo(hijriOptions).GetActivePrayerTracking(time.Now())
+		if err != nil {
+			return err
+		}
+		if output != ui.OutputTable {
+			return ui.RenderActivePrayerTrackingJSON(activePrayerTracking, output)
+		}
+		return ui.RenderNextPrayer(activePrayerTracking, format, seconds)
+	},
+}
+
+func init() {
+	addTemplateFlags(nextCmd, ui.DefaultNextPrayerFormat)
+	nextCmd.Flags().Bool("seconds", false, "Print remaining time in seconds")
+}
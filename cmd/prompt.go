@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultPromptFormat is short, prompts have little room
+const defaultPromptFormat = "{{.Name}} {{.Remaining}}"
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a short next prayer segment for shell prompts",
+	Long: `Print a short next prayer segment like "Asr 1h12m", without new line, for
+starship, powerlevel10k or PS1. It is fast enough to run on every prompt
+redraw: it reads only a tiny snapshot of today and tomorrow, never year data
+and never the network.
+
+Snapshot is refreshed by any other prayers command, so run one at least once a
+day. If snapshot is missing or out of date, nothing is printed.
+
+  PS1='$(prayers prompt) \$ '
+
+  # starship.toml
+  [custom.prayers]
+  command = "prayers prompt"
+  when = true
+
+Segment can be customized with --format, with same fields and helpers as next command`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if _, err := getHijriOptions(cmd); err != nil {
+			return err
+		}
+
+		activePrayerTracking, err := domain.GetSnapshotTracking(storage.DefaultSnapshotStorage(), time.Now())
+		if errors.Is(err, domain.ErrNoSnapshot) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		segment, err := ui.FormatNextPrayer(activePrayerTracking, format, false)
+		if err != nil {
+			return err
+		}
+		fmt.Print(segment)
+		return nil
+	},
+}
+
+func init() {
+	promptCmd.Flags().String("format", defaultPromptFormat, "Go template of segment, see help of next command")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var rangeCmd = &cobra.Command{
+	Use:   "range",
+	Short: "Get prayer times of a date range",
+	Long: `Get prayer times of each day from --from to --to (inclusive). Dates are
+written like date argument of root command, e.g. 2026-03-20, 20/03, tomorrow
+or +30d. Range can span multiple years
+
+With --format or --template-file, template is executed for each day, with
+fields .Date, .Hijri, .Event, .Prayers and .Sunrise:
+  prayers range --to +6d --format '{{fmtTime "Mon 02/01" .Date}} {{hijri .Hijri}}'
+
+` + templateHelp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		from, to, err := getDateRange(cmd)
+		if err != nil {
+			return err
+		}
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		tmpl, err := getTemplate(cmd)
+		if err != nil {
+			return err
+		}
+
+		schedules := []domain.DailyPrayerSchedule{}
+		for schedule, err := range createPrayerTimesRepo(hijriOptions).GetSchedules(from, to) {
+			if err != nil {
+				return err
+			}
+			// templates and ndjson are streamed a day at a time
+			if tmpl != "" {
+				if err := ui.RenderTemplate(tmpl, schedule); err != nil {
+					return err
+				}
+				continue
+			}
+			if output == ui.OutputNDJSON {
+				if err := ui.RenderScheduleJSON(schedule, output); err != nil {
+					return err
+				}
+				continue
+			}
+			schedules = append(schedules, schedule)
+		}
+
+		switch {
+		case tmpl != "":
+		case output == ui.OutputJSON:
+			return ui.RenderSchedulesJSON(schedules, output)
+		case output == ui.OutputTable:
+			ui.RenderTimetable(schedules, time.Now())
+		}
+		return nil
+	},
+}
+
+// getDateRange reads --from and --to flags. Both default to today
+func getDateRange(cmd *cobra.Command) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	parse := func(flag string) (time.Time, error) {
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if value == "" {
+			return today, nil
+		}
+		return domain.ParseDate(value, now)
+	}
+
+	from, err := parse("from")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parse("to")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("--to can not be before --from")
+	}
+	return from, to, nil
+}
+
+func init() {
+	rangeCmd.Flags().String("from", "", "First day of range, e.g. 2025-12-25 (default today)")
+	rangeCmd.Flags().String("to", "", "Last day of range, e.g. 2026-01-05 (default today)")
+	addTemplateFlags(rangeCmd, "")
+}
@@ -4,8 +4,10 @@ Copyright © 2025 MABD-dev <mabd.universe@gmail.com>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
@@ -15,38 +17,55 @@ import (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "prayers",
+	Use:   "prayers [date]",
 	Short: "Get prayer times for today",
+	Long: `Get prayer times for today, or for the date given as argument or flags.
+Date argument can be: today, tomorrow, yesterday, friday, next friday,
+last friday, +10d, 2w ago, 2026-03-20, 20/03 or 20/03/2026. Negative forms
+like -2w are taken for flags, pass them after --:
+  prayers 2w ago
+  prayers -- -2w
+
+Template data of today is tracking of active prayer, with fields .Date, .Hijri,
+.Event, .Prayers, .Sunrise, .PreviousPrayer, .PreviousPrayerTime, .NextPrayer,
+.NextPrayerTime, .TimeRemaining and .Progress. Other days have only the first 5:
+  prayers --format '{{.NextPrayer}} in {{until .NextPrayerTime}}'
+
+` + templateHelp,
+	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
 
-		year, err := cmd.Flags().GetInt("year")
+		requestedDate, err := getRequestedDate(cmd, args, hijriOptions, false)
 		if err != nil {
-			panic(err)
+			return err
 		}
-		month, err := cmd.Flags().GetInt("month")
+		output, err := getOutputFormat(cmd)
 		if err != nil {
 			return err
 		}
-		day, err := cmd.Flags().GetInt("day")
+		tmpl, err := getTemplate(cmd)
 		if err != nil {
 			return err
 		}
+		repo := createPrayerTimesRepo(hijriOptions)
 
 		now := time.Now()
-		requestedDate := time.Date(year, time.Month(month), day, now.Hour(), now.Minute(), 0, 0, now.Location())
-
-		localYearFilename := fmt.Sprintf("%v.json", requestedDate.Year())
-		var storage storage.Storage = &storage.FileStorage{
-			FileName: localYearFilename,
-		}
-		var repo domain.PrayerTimesRepo = domain.CreatePrayerTimesRepo(storage)
-
 		isToday := domain.SameDay(now, requestedDate)
 		if isToday {
 			activePrayerTracking, err := repo.GetActivePrayerTracking(requestedDate)
 			if err != nil {
 				return err
 			}
+			if output != ui.OutputTable {
+				return ui.RenderActivePrayerTrackingJSON(activePrayerTracking, output)
+			}
+			if tmpl != "" {
+				return ui.RenderTemplate(tmpl, activePrayerTracking)
+			}
 			ui.RenderActivePrayerTracking(activePrayerTracking)
 
 		} else {
@@ -54,6 +73,12 @@ var rootCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
+			if output != ui.OutputTable {
+				return ui.RenderScheduleJSON(dailyPrayerSchedule, output)
+			}
+			if tmpl != "" {
+				return ui.RenderTemplate(tmpl, dailyPrayerSchedule)
+			}
 			ui.RenderDailyPrayerSchedule(dailyPrayerSchedule)
 		}
 		return nil
@@ -70,10 +95,138 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.AddCommand()
+	rootCmd.AddCommand(eventsCmd, weekCmd, monthCmd, rangeCmd, nextCmd, exportCmd, serveICSCmd, barCmd, promptCmd, watchCmd, tuiCmd, daemonCmd, stopCmd, scheduleCmd, fireCmd)
+
+	addTemplateFlags(rootCmd, "")
 
 	now := time.Now()
 	rootCmd.PersistentFlags().IntP("year", "y", now.Year(), "Set year")
 	rootCmd.PersistentFlags().IntP("month", "m", int(now.Month()), "Set month")
 	rootCmd.PersistentFlags().IntP("day", "d", now.Day(), "Set day")
+	rootCmd.PersistentFlags().String("hijri", "", "Set hijri date instead of gregorian one, e.g. 1447-09-27")
+	rootCmd.PersistentFlags().StringP("output", "o", string(ui.OutputTable), "Output format (table|json|ndjson)")
+	rootCmd.PersistentFlags().String("hijri-lang", string(domain.HijriLanguageEn), "Hijri month names language (en|ar)")
+	rootCmd.PersistentFlags().Int("hijri-offset", 0, "Days to shift converted hijri dates by, when data has none")
+	rootCmd.PersistentFlags().Bool("hijri-maghrib", false, "Advance to next hijri date after maghrib")
+}
+
+// getRequestedDate reads date from @args or date flags (--year, --month, --day
+// or --hijri) and returns requested date at current time of day. Without
+// --day, day is first of month if @wholeMonth is requested, or today's day
+// clamped to length of month
+func getRequestedDate(cmd *cobra.Command, args []string, hijriOptions domain.HijriOptions, wholeMonth bool) (time.Time, error) {
+	year, err := cmd.Flags().GetInt("year")
+	if err != nil {
+		return time.Time{}, err
+	}
+	month, err := cmd.Flags().GetInt("month")
+	if err != nil {
+		return time.Time{}, err
+	}
+	day, err := cmd.Flags().GetInt("day")
+	if err != nil {
+		return time.Time{}, err
+	}
+	hijriStr, err := cmd.Flags().GetString("hijri")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	dateFlagsChanged := cmd.Flags().Changed("year") || cmd.Flags().Changed("month") || cmd.Flags().Changed("day")
+
+	now := time.Now()
+	if len(args) > 0 {
+		if dateFlagsChanged || hijriStr != "" {
+			return time.Time{}, errors.New("date argument can not be combined with --year, --month, --day or --hijri")
+		}
+		date, err := domain.ParseDate(strings.Join(args, " "), now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, now.Location()), nil
+	}
+
+	if hijriStr == "" {
+		if !cmd.Flags().Changed("day") && month >= 1 && month <= 12 {
+			day = min(day, domain.DaysInMonth(year, time.Month(month)))
+			if wholeMonth {
+				day = 1
+			}
+		}
+		date, err := domain.NewDate(year, month, day, now.Location())
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, now.Location()), nil
+	}
+
+	if dateFlagsChanged {
+		return time.Time{}, errors.New("--hijri can not be combined with --year, --month or --day")
+	}
+	hijri, err := domain.ParseHijriDate(hijriStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	date, err := createPrayerTimesRepo(hijriOptions).FindHijriDate(hijri)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, now.Location()), nil
+}
+
+// createPrayerTimesRepo creates repo backed by a local file per year, that
+// keeps snapshot for prompt command fresh
+func createPrayerTimesRepo(hijriOptions domain.HijriOptions) domain.PrayerTimesRepo {
+	return domain.CreatePrayerTimesRepo(storage.YearFileStorage, storage.DefaultSnapshotStorage(), hijriOptions)
+}
+
+// getOutputFormat reads and validates --output flag
+func getOutputFormat(cmd *cobra.Command) (ui.OutputFormat, error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+	return ui.ParseOutputFormat(output)
+}
+
+// rejectOutputFormat returns error if --output asks for other than table
+// output, for commands that have no json output
+func rejectOutputFormat(cmd *cobra.Command) error {
+	output, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if output != ui.OutputTable {
+		return fmt.Errorf("--output %v is not supported by %v", output, cmd.CommandPath())
+	}
+	return nil
+}
+
+// getHijriOptions reads hijri flags, and set hijri language for ui
+func getHijriOptions(cmd *cobra.Command) (domain.HijriOptions, error) {
+	lang, err := cmd.Flags().GetString("hijri-lang")
+	if err != nil {
+		return domain.HijriOptions{}, err
+	}
+	switch domain.HijriLanguage(lang) {
+	case domain.HijriLanguageEn, domain.HijriLanguageAr:
+		ui.HijriLanguage = domain.HijriLanguage(lang)
+	default:
+		return domain.HijriOptions{}, fmt.Errorf("invalid hijri language %q, expected en or ar", lang)
+	}
+
+	offset, err := cmd.Flags().GetInt("hijri-offset")
+	if err != nil {
+		return domain.HijriOptions{}, err
+	}
+	advanceAfterMaghrib, err := cmd.Flags().GetBool("hijri-maghrib")
+	if err != nil {
+		return domain.HijriOptions{}, err
+	}
+
+	return domain.HijriOptions{
+		Offset:              offset,
+		AdvanceAfterMaghrib: advanceAfterMaghrib,
+	}, nil
 }
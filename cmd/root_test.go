@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// executeRequestedDate runs root command with @args, returning date it would
+// show prayer times of
+func executeRequestedDate(t *testing.T, args ...string) (time.Time, error) {
+	t.Helper()
+	runE := rootCmd.RunE
+	t.Cleanup(func() {
+		rootCmd.RunE = runE
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	var date time.Time
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		var err error
+		date, err = getRequestedDate(cmd, args, domain.HijriOptions{}, false)
+		return err
+	}
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	err := rootCmd.Execute()
+	return date, err
+}
+
+func TestRootRelativeDateArgs(t *testing.T) {
+	twoWeeksAgo := time.Now().AddDate(0, 0, -14)
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "Ago", args: []string{"2w", "ago"}},
+		{name: "Negative after double dash", args: []string{"--", "-2w"}},
+		{name: "Negative taken for flag", args: []string{"-2w"}, expectedError: "unknown shorthand flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := executeRequestedDate(t, tt.args...)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error %q but got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !domain.SameDay(date, twoWeeksAgo) {
+				t.Errorf("Expected %v but got %v", twoWeeksAgo.Format(time.DateOnly), date.Format(time.DateOnly))
+			}
+		})
+	}
+}
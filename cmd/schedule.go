@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/daemon"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/schedule"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Schedule notifications, hooks and adhan with systemd timers or cron, without daemon",
+	Long: `Install a systemd user timer per prayer event of coming days, or crontab
+lines with --cron, each running prayers fire at event time to send
+notifications, run hooks and play adhan as daemon would. Events come from
+same config as daemon, see prayers daemon --help. Hijri flags given to
+install are passed on to prayers fire and to daily install.
+
+A daily timer, or crontab line, at 00:05 installs events of coming days
+again, so config changes are picked up by next day. Run install again to
+apply them right away.
+
+Systemd units go to ~/.config/systemd/user, named prayers-*`,
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install timers, or crontab lines, of prayer events of coming days",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		times, options, err := getScheduleEvents(cmd)
+		if err != nil {
+			return err
+		}
+		useCron, err := cmd.Flags().GetBool("cron")
+		if err != nil {
+			return err
+		}
+
+		if useCron {
+			if err := updateCrontab(schedule.CrontabBlock(times, options)); err != nil {
+				return err
+			}
+			fmt.Printf("Installed %v crontab lines of prayer events\n", len(times))
+			return nil
+		}
+
+		dir, err := systemdUserDir()
+		if err != nil {
+			return err
+		}
+		units := schedule.SystemdUnits(times, options)
+		stale, err := schedule.StaleUnits(dir, units)
+		if err != nil {
+			return err
+		}
+		if err := disableTimers(stale); err != nil {
+			return err
+		}
+		if err := schedule.WriteSystemdUnits(dir, units); err != nil {
+			return err
+		}
+		if err := systemctl("daemon-reload"); err != nil {
+			return err
+		}
+		if err := systemctl(append([]string{"enable", "--now"}, schedule.Timers(units)...)...); err != nil {
+			return err
+		}
+		fmt.Printf("Installed %v timers of prayer events to %v\n", len(times), dir)
+		return nil
+	},
+}
+
+var scheduleUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove installed timers, or crontab lines",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		useCron, err := cmd.Flags().GetBool("cron")
+		if err != nil {
+			return err
+		}
+		if useCron {
+			if err := updateCrontab(""); err != nil {
+				return err
+			}
+			fmt.Println("Removed crontab lines of prayer events")
+			return nil
+		}
+
+		dir, err := systemdUserDir()
+		if err != nil {
+			return err
+		}
+		installed, err := schedule.InstalledUnits(dir)
+		if err != nil {
+			return err
+		}
+		if len(installed) == 0 {
+			fmt.Println("No timers are installed")
+			return nil
+		}
+		if err := disableTimers(installed); err != nil {
+			return err
+		}
+		if err := schedule.RemoveUnits(dir, installed); err != nil {
+			return err
+		}
+		if err := systemctl("daemon-reload"); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %v units from %v\n", len(installed), dir)
+		return nil
+	},
+}
+
+var scheduleShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print timers, or crontab lines, install would write",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectOutputFormat(cmd); err != nil {
+			return err
+		}
+		times, options, err := getScheduleEvents(cmd)
+		if err != nil {
+			return err
+		}
+		useCron, err := cmd.Flags().GetBool("cron")
+		if err != nil {
+			return err
+		}
+
+		if useCron {
+			fmt.Print(schedule.CrontabBlock(times, options))
+			return nil
+		}
+		for i, unit := range schedule.SystemdUnits(times, options) {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("# %v\n%v", unit.Name, unit.Content)
+		}
+		return nil
+	},
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleInstallCmd, scheduleUninstallCmd, scheduleShowCmd)
+	scheduleCmd.PersistentFlags().Bool("cron", false, "Use crontab instead of systemd timers")
+	for _, cmd := range []*cobra.Command{scheduleInstallCmd, scheduleShowCmd} {
+		cmd.Flags().Int("days", 2, "Days ahead to schedule events for, starting today")
+	}
+}
+
+// getScheduleEvents returns times of events from now to end of --days, and
+// options of units calling this executable with same hijri flags
+func getScheduleEvents(cmd *cobra.Command) ([]time.Time, schedule.Options, error) {
+	days, err := cmd.Flags().GetInt("days")
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+	if days < 1 {
+		return nil, schedule.Options{}, fmt.Errorf("invalid days %v, expected at least 1", days)
+	}
+	hijriOptions, err := getHijriOptions(cmd)
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+	configPath, err := config.Path()
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+
+	// handlers are only asked for triggers here
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	handlers := eventHandlers(nil, &daemon.HookHandler{Log: logger}, &daemon.AdhanHandler{Log: logger}, &daemon.WebhookHandler{Log: logger}, logger)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	times, err := daemon.EventTimes(createPrayerTimesRepo(hijriOptions), cfg, handlers, now, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, schedule.Options{}, err
+	}
+	options := schedule.Options{
+		Command:      executable,
+		Days:         days,
+		HijriOffset:  hijriOptions.Offset,
+		HijriMaghrib: hijriOptions.AdvanceAfterMaghrib,
+	}
+	if ui.HijriLanguage != domain.HijriLanguageEn {
+		options.HijriLang = string(ui.HijriLanguage)
+	}
+	return times, options, nil
+}
+
+// systemdUserDir is where units of user service manager are read from
+func systemdUserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "systemd", "user"), nil
+}
+
+// disableTimers stops and disables timers among @units
+func disableTimers(units []string) error {
+	var timers []string
+	for _, unit := range units {
+		if strings.HasSuffix(unit, ".timer") {
+			timers = append(timers, unit)
+		}
+	}
+	if len(timers) == 0 {
+		return nil
+	}
+	return systemctl(append([]string{"disable", "--now"}, timers...)...)
+}
+
+// systemctl runs systemctl of user service manager with @args
+func systemctl(args ...string) error {
+	cmd := exec.Command("systemctl", append([]string{"--user"}, args...)...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("systemctl %v: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// updateCrontab replaces crontab lines of previous install with @block
+func updateCrontab(block string) error {
+	var current bytes.Buffer
+	list := exec.Command("crontab", "-l")
+	list.Stdout = &current
+	if err := list.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("crontab -l: %w", err)
+		}
+		// user has no crontab yet
+		current.Reset()
+	}
+
+	install := exec.Command("crontab", "-")
+	install.Stdin = strings.NewReader(schedule.ReplaceCrontabBlock(current.String(), block))
+	install.Stderr = os.Stderr
+	if err := install.Run(); err != nil {
+		return fmt.Errorf("crontab: %w", err)
+	}
+	return nil
+}
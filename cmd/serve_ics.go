@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/feed"
+	"github.com/spf13/cobra"
+)
+
+var serveICSCmd = &cobra.Command{
+	Use:   "serve-ics",
+	Short: "Serve a rolling iCalendar feed calendar apps can subscribe to",
+	Long: `Serve a rolling iCalendar feed at /calendar/{location}.ics, starting today and
+spanning --days days. Calendar apps subscribing to it stay up to date without
+importing files again.
+
+Query params:
+  prayers=fajr,maghrib   prayers to include, default all
+  alarm=10,5             reminders in minutes before each prayer
+  duration=20            minutes each prayer event blocks
+  days=60                number of days starting today
+
+ETag changes with data source sha1, so clients only download changes. When
+data of next year is not published yet, calendar ends at end of this year`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, err := cmd.Flags().GetString("addr")
+		if err != nil {
+			return err
+		}
+		location, err := cmd.Flags().GetString("location")
+		if err != nil {
+			return err
+		}
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 || days > feed.MaxDays {
+			return fmt.Errorf("invalid days %v, expected 1 to %v", days, feed.MaxDays)
+		}
+		duration, err := cmd.Flags().GetDuration("duration")
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		server := &feed.Server{
+			Location: location,
+			Days:     days,
+			Duration: duration,
+			CreateRepo: func() domain.PrayerTimesRepo {
+				return createPrayerTimesRepo(hijriOptions)
+			},
+			Now: time.Now,
+		}
+
+		fmt.Fprintf(os.Stderr, "Serving http://%v/calendar/%v.ics\n", addr, location)
+		return http.ListenAndServe(addr, server.Handler())
+	},
+}
+
+func init() {
+	serveICSCmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	serveICSCmd.Flags().String("location", "default", "Location name, served as /calendar/{location}.ics")
+	serveICSCmd.Flags().Int("days", 60, "Days calendar spans starting today")
+	serveICSCmd.Flags().Duration("duration", 20*time.Minute, "Event duration of each prayer")
+}
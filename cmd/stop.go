@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop adhan played by daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pidFile, err := adhanPIDFile()
+		if err != nil {
+			return err
+		}
+		stopped, err := daemon.StopAdhan(pidFile)
+		if err != nil {
+			return err
+		}
+		if stopped {
+			fmt.Println("Stopped adhan")
+		} else {
+			fmt.Println("No adhan is playing")
+		}
+		return nil
+	},
+}
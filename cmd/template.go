@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const templateHelp = `Output can be customized with a Go template, given inline with --format or in a
+file with --template-file. Besides data fields, templates can use:
+  fmtTime "15:04" .Date    format time with Go layout
+  until .NextPrayerTime    time left to given time, like 1h12m
+  duration .TimeRemaining  format duration like 1h12m
+  hijri .Hijri             hijri date in --hijri-lang
+  arabic .NextPrayer       arabic prayer name, event, date or time
+  pad 8 .NextPrayer        pad to 8 characters, -8 pads on the left`
+
+// addTemplateFlags adds --format and --template-file flags to @cmd, with
+// @formatDefault as default of --format
+func addTemplateFlags(cmd *cobra.Command, formatDefault string) {
+	cmd.Flags().String("format", formatDefault, "Go template of output, see help for fields and helpers")
+	cmd.Flags().String("template-file", "", "Read Go template of output from file")
+}
+
+// getTemplate returns template set by --format or --template-file, or empty
+// string if none is set. Default of --format is not returned, so commands
+// without template flags, or with a default format, can tell when user set one
+func getTemplate(cmd *cobra.Command) (string, error) {
+	formatChanged := cmd.Flags().Changed("format")
+	fileChanged := cmd.Flags().Changed("template-file")
+	if !formatChanged && !fileChanged {
+		return "", nil
+	}
+	if formatChanged && fileChanged {
+		return "", errors.New("--format can not be combined with --template-file")
+	}
+
+	output, err := getOutputFormat(cmd)
+	if err != nil {
+		return "", err
+	}
+	if output != ui.OutputTable {
+		return "", errors.New("--format and --template-file can not be combined with --output json or ndjson")
+	}
+
+	if formatChanged {
+		return cmd.Flags().GetString("format")
+	}
+	path, err := cmd.Flags().GetString("template-file")
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse prayer times in an interactive full screen view",
+	Long: `Browse prayer times in an interactive full screen view, opening on today.
+Side panel shows hijri date, events, night times and prayer log.
+
+Keys:
+  h/l, left/right   previous and next day
+  j/k, down/up      next and previous week
+  t                 today
+  /                 search a date, e.g. 2026-03-20, 20/03, next friday, or a
+                    hijri date, e.g. 1447-09-27 or 27/09/1447
+  1-5               log fajr, dhuhr, asr, maghrib or isha of day as prayed
+  q                 quit`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectOutputFormat(cmd); err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			return errors.New("tui needs a terminal")
+		}
+
+		prayerLog, err := domain.LoadPrayerLog(storage.DefaultPrayerLogStorage())
+		if err != nil {
+			return err
+		}
+		return ui.RunTUI(ui.NewTUI(createPrayerTimesRepo(hijriOptions), prayerLog, time.Now))
+	},
+}
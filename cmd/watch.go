@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultWatchWidth is used when terminal size is unknown
+const defaultWatchWidth = 80
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep prayer times on screen with a live countdown",
+	Long: `Keep today prayer times on screen, redrawing countdown and progress bar every
+second. View rolls over to next prayer when its time comes, and to next day
+at midnight. With --title, terminal title shows next prayer and time left.
+Quit with Ctrl-C`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectOutputFormat(cmd); err != nil {
+			return err
+		}
+		title, err := cmd.Flags().GetBool("title")
+		if err != nil {
+			return err
+		}
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			return errors.New("watch needs a terminal, try next or bar commands for scripts")
+		}
+
+		repo := createPrayerTimesRepo(hijriOptions)
+		activePrayerTracking, err := repo.GetActivePrayerTracking(time.Now())
+		if err != nil {
+			return err
+		}
+
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		defer signal.Stop(quit)
+		resize := make(chan os.Signal, 1)
+		notifyResize(resize)
+		defer signal.Stop(resize)
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		ui.EnterWatchScreen(os.Stdout, title)
+		defer ui.ExitWatchScreen(os.Stdout, title)
+
+		for {
+			now := time.Now()
+			// roll over at each prayer, and at midnight to show the new day
+			if !now.Before(activePrayerTracking.NextPrayerTime) || !domain.SameDay(now, activePrayerTracking.Date) {
+				activePrayerTracking, err = repo.GetActivePrayerTracking(now)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := ui.RenderWatchFrame(os.Stdout, activePrayerTracking.At(now), terminalWidth(), title); err != nil {
+				return err
+			}
+
+			select {
+			case <-quit:
+				return nil
+			case <-resize:
+				ui.ClearWatchScreen(os.Stdout)
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// terminalWidth returns columns of terminal stdout is attached to
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return defaultWatchWidth
+	}
+	return width
+}
+
+func init() {
+	watchCmd.Flags().Bool("title", false, "Show next prayer and time left in terminal title")
+}
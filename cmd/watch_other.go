@@ -0,0 +1,9 @@
+//go:build !unix
+
+package cmd
+
+import "os"
+
+// notifyResize does nothing where terminals do not signal resize, width is
+// read again on every redraw anyway
+func notifyResize(c chan os.Signal) {}
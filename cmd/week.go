@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var weekCmd = &cobra.Command{
+	Use:   "week [date]",
+	Args:  cobra.MaximumNArgs(2),
+	Short: "Get prayer times of the week",
+	Long: `Get prayer times of the week requested date falls in, with a row for each day.
+Today and next prayer are highlighted`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hijriOptions, err := getHijriOptions(cmd)
+		if err != nil {
+			return err
+		}
+		requestedDate, err := getRequestedDate(cmd, args, hijriOptions, false)
+		if err != nil {
+			return err
+		}
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		schedules, err := createPrayerTimesRepo(hijriOptions).GetWeekPrayerSchedules(requestedDate)
+		if err != nil {
+			return err
+		}
+		if output != ui.OutputTable {
+			return ui.RenderSchedulesJSON(schedules, output)
+		}
+		ui.RenderWeek(schedules, time.Now())
+		return nil
+	},
+}
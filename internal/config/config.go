@@ -0,0 +1,535 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// FileName of config file, kept next to year files
+const FileName = "config.json"
+
+// Config is user configuration read by long running commands like daemon.
+// Every field is optional, zero value is the default behaviour
+type Config struct {
+	Notifications Notifications `json:"notifications"`
+	Hooks         Hooks         `json:"hooks"`
+	Adhan         Adhan         `json:"adhan"`
+	Webhooks      Webhooks      `json:"webhooks"`
+}
+
+// Notifications configures desktop notifications sent by daemon
+type Notifications struct {
+	// Disabled turns notifications off, other daemon features keep running
+	Disabled bool `json:"disabled"`
+
+	// Prayers to notify at, all prayers if empty, e.g. ["fajr", "maghrib"]
+	Prayers []string `json:"prayers"`
+
+	// Before are lead times to also notify at before each prayer, e.g. ["15m", "5m"]
+	Before []Duration `json:"before"`
+
+	// Reminders, if set, replace @Prayers and @Before with notifications of
+	// each prayer they name. Sunrise is notified only through reminders
+	Reminders []Reminder `json:"reminders"`
+}
+
+// Reminder is a notification at or before a prayer, e.g.
+//
+//	{"prayer": "asr", "before": "15m", "escalate": {"after": "30m"}}
+type Reminder struct {
+	Prayer string `json:"prayer"`
+
+	// Before is lead time to prayer, zero notifies at prayer time
+	Before Duration `json:"before"`
+
+	// Escalate notifies again if prayer is not logged as prayed in time
+	Escalate *Escalation `json:"escalate"`
+}
+
+// Escalation is a louder notification sent if prayer of a reminder is not
+// logged as prayed @After reminder, but not before prayer time as a prayer
+// can not be logged earlier. Prayer of a sunrise reminder is fajr
+type Escalation struct {
+	After Duration `json:"after"`
+
+	// Urgency of notification, critical if empty
+	Urgency Urgency `json:"urgency"`
+
+	// Persistent notifications stay until dismissed
+	Persistent bool `json:"persistent"`
+}
+
+// Urgency of a notification as in freedesktop notifications spec
+type Urgency string
+
+const (
+	UrgencyLow      Urgency = "low"
+	UrgencyNormal   Urgency = "normal"
+	UrgencyCritical Urgency = "critical"
+)
+
+// Hooks configures commands daemon runs at prayer events
+type Hooks struct {
+	Commands []Hook `json:"commands"`
+
+	// Timeout kills a command running longer, 30s if zero
+	Timeout Duration `json:"timeout"`
+
+	// Concurrency caps commands running at once, others wait, 4 if zero
+	Concurrency int `json:"concurrency"`
+
+	// Log is file hooks runs and output are appended to, hooks.log next to
+	// config if empty
+	Log string `json:"log"`
+}
+
+const (
+	defaultHookTimeout     = 30 * time.Second
+	defaultHookConcurrency = 4
+)
+
+// Hook is a command run by shell at a prayer event, e.g.
+//
+//	{"on": "before:asr:10m", "run": "playerctl pause"}
+type Hook struct {
+	On  HookEvent `json:"on"`
+	Run string    `json:"run"`
+
+	// Timeout overrides @Hooks.Timeout for this command
+	Timeout Duration `json:"timeout"`
+}
+
+// HookEvent is when a hook runs, one of at:prayer, before:prayer:duration
+// and after:prayer:duration, e.g. at:maghrib or before:asr:10m
+type HookEvent string
+
+// Parse returns prayer name and offset from prayer time of event
+func (e HookEvent) Parse() (string, time.Duration, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(string(e))), ":")
+	if len(parts) < 2 {
+		return "", 0, fmt.Errorf("invalid hook event %q, expected e.g. at:maghrib or before:asr:10m", e)
+	}
+
+	prayer, err := PrayerName(parts[1])
+	if err != nil {
+		return "", 0, err
+	}
+
+	switch {
+	case parts[0] == "at" && len(parts) == 2:
+		return prayer, 0, nil
+	case (parts[0] == "before" || parts[0] == "after") && len(parts) == 3:
+		offset, err := time.ParseDuration(parts[2])
+		if err != nil || offset <= 0 {
+			return "", 0, fmt.Errorf("invalid duration %q in hook event %q", parts[2], e)
+		}
+		if parts[0] == "before" {
+			offset = -offset
+		}
+		return prayer, offset, nil
+	}
+	return "", 0, fmt.Errorf("invalid hook event %q, expected e.g. at:maghrib or before:asr:10m", e)
+}
+
+// HookTimeout returns timeout of @hook, falling back to hooks and default timeout
+func (h Hooks) HookTimeout(hook Hook) time.Duration {
+	switch {
+	case hook.Timeout > 0:
+		return time.Duration(hook.Timeout)
+	case h.Timeout > 0:
+		return time.Duration(h.Timeout)
+	}
+	return defaultHookTimeout
+}
+
+// MaxConcurrency returns @Concurrency or its default
+func (h Hooks) MaxConcurrency() int {
+	if h.Concurrency > 0 {
+		return h.Concurrency
+	}
+	return defaultHookConcurrency
+}
+
+// LogPath returns @Log or its default
+func (h Hooks) LogPath() (string, error) {
+	if h.Log != "" {
+		return h.Log, nil
+	}
+	return storage.FilePath("hooks.log")
+}
+
+// Adhan configures adhan daemon plays at prayer times
+type Adhan struct {
+	// File played at each prayer, adhan is off if it and @FajrFile are empty
+	File string `json:"file"`
+
+	// FajrFile is played at fajr instead of @File
+	FajrFile string `json:"fajrFile"`
+
+	// Player command, file is passed in place of a {file} argument or after
+	// last argument. First installed of mpv, paplay and ffplay if empty
+	Player []string `json:"player"`
+
+	// Prayers turns adhan of each prayer on or off, all are on by default,
+	// e.g. {"dhuhr": false}
+	Prayers map[string]bool `json:"prayers"`
+
+	// Mute are daily time ranges adhan is not played in
+	Mute []MuteRange `json:"mute"`
+}
+
+// MuteRange is a daily time range, e.g. {"from": "22:00", "to": "06:00"}
+type MuteRange struct {
+	From ClockTime `json:"from"`
+	To   ClockTime `json:"to"`
+
+	// Days range applies on, every day if empty, e.g. ["sat", "sun"]. A range
+	// crossing midnight belongs to day it starts on
+	Days []string `json:"days"`
+}
+
+// PrayerFile returns file to play at prayer named @name, or "" if adhan of
+// prayer is off
+func (a Adhan) PrayerFile(name string) string {
+	for prayer, enabled := range a.Prayers {
+		if prayerName, err := PrayerName(prayer); err == nil && prayerName == name && !enabled {
+			return ""
+		}
+	}
+	if name == models.SortedPrayerNames[0] && a.FajrFile != "" {
+		return expandHome(a.FajrFile)
+	}
+	return expandHome(a.File)
+}
+
+// Muted checks if @t is in one of mute ranges
+func (a Adhan) Muted(t time.Time) bool {
+	clock := ClockTime(t.Hour()*60 + t.Minute())
+	yesterday := t.AddDate(0, 0, -1).Weekday()
+	for _, r := range a.Mute {
+		switch {
+		case r.From <= r.To:
+			if r.onDay(t.Weekday()) && clock >= r.From && clock < r.To {
+				return true
+			}
+		case clock >= r.From:
+			if r.onDay(t.Weekday()) {
+				return true
+			}
+		case clock < r.To:
+			if r.onDay(yesterday) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r MuteRange) onDay(day time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.Days, func(d string) bool {
+		weekday, err := parseWeekday(d)
+		return err == nil && weekday == day
+	})
+}
+
+// parseWeekday matches full or 3 letter weekday names, e.g. fri or Friday
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.TrimSpace(s)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(s, day.String()) || strings.EqualFold(s, day.String()[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// Webhooks configures JSON payloads daemon posts at prayer events
+type Webhooks struct {
+	Endpoints []Webhook `json:"endpoints"`
+
+	// Location is sent in payloads, e.g. "Beirut"
+	Location string `json:"location"`
+
+	// Missed is what happens to events missed while machine was suspended,
+	// dropped by default. Events that passed while daemon was not running are
+	// not posted at all
+	Missed MissedPolicy `json:"missed"`
+
+	// Attempts caps posts of a payload before it is dropped, 8 if zero
+	Attempts int `json:"attempts"`
+
+	// MaxAge drops payloads not delivered this long after their event, 24h if zero
+	MaxAge Duration `json:"maxAge"`
+}
+
+const (
+	defaultWebhookAttempts = 8
+	defaultWebhookMaxAge   = 24 * time.Hour
+)
+
+// Webhook posts events of @On to @URL, e.g.
+//
+//	{"url": "https://bot.example.com/prayers", "secret": "s3cret", "on": ["at:maghrib"]}
+type Webhook struct {
+	URL string `json:"url"`
+
+	// Secret signs payloads with HMAC-SHA256, unsigned if empty
+	Secret string `json:"secret"`
+
+	// On are events posted, as in hooks, e.g. ["at:maghrib", "before:asr:10m"]
+	On []HookEvent `json:"on"`
+}
+
+// MissedPolicy is what happens to webhook events missed while suspended
+type MissedPolicy string
+
+const (
+	MissedDrop    MissedPolicy = "drop"
+	MissedDeliver MissedPolicy = "deliver"
+)
+
+// MaxAttempts returns @Attempts or its default
+func (w Webhooks) MaxAttempts() int {
+	if w.Attempts > 0 {
+		return w.Attempts
+	}
+	return defaultWebhookAttempts
+}
+
+// DeliveryMaxAge returns @MaxAge or its default
+func (w Webhooks) DeliveryMaxAge() time.Duration {
+	if w.MaxAge > 0 {
+		return time.Duration(w.MaxAge)
+	}
+	return defaultWebhookMaxAge
+}
+
+// ClockTime is minutes since midnight, written in config as "HH:MM"
+type ClockTime int
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", c/60, c%60)
+}
+
+func (c ClockTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *ClockTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("time must be a string like \"22:00\": %w", err)
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return fmt.Errorf("invalid time %q, expected e.g. \"22:00\"", s)
+	}
+	*c = ClockTime(t.Hour()*60 + t.Minute())
+	return nil
+}
+
+// expandHome replaces leading ~ of @path with home dir
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// Duration is a @time.Duration written in config as a string, e.g. "15m"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"15m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if parsed < 0 {
+		return fmt.Errorf("duration %q must not be negative", s)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Path returns full path of config file, which may not exist
+func Path() (string, error) {
+	return storage.FilePath(FileName)
+}
+
+// Load reads config at @path, returning default config if file does not exist
+func Load(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid config %v: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return config, fmt.Errorf("invalid config %v: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks prayer names, reminders, hooks, adhan and webhooks used in config
+func (c Config) Validate() error {
+	for _, name := range c.Notifications.Prayers {
+		if _, err := PrayerName(name); err != nil {
+			return fmt.Errorf("notifications: %w", err)
+		}
+	}
+	for i, reminder := range c.Notifications.Reminders {
+		if err := reminder.validate(); err != nil {
+			return fmt.Errorf("notifications: reminder %v: %w", i+1, err)
+		}
+	}
+	for i, hook := range c.Hooks.Commands {
+		if _, _, err := hook.On.Parse(); err != nil {
+			return fmt.Errorf("hooks: command %v: %w", i+1, err)
+		}
+		if strings.TrimSpace(hook.Run) == "" {
+			return fmt.Errorf("hooks: command %v: run must be set", i+1)
+		}
+	}
+	if c.Hooks.Concurrency < 0 {
+		return errors.New("hooks: concurrency must not be negative")
+	}
+	for prayer := range c.Adhan.Prayers {
+		if _, err := PrayerName(prayer); err != nil {
+			return fmt.Errorf("adhan: %w", err)
+		}
+	}
+	for _, r := range c.Adhan.Mute {
+		for _, day := range r.Days {
+			if _, err := parseWeekday(day); err != nil {
+				return fmt.Errorf("adhan: mute: %w", err)
+			}
+		}
+	}
+	for i, webhook := range c.Webhooks.Endpoints {
+		if err := webhook.validate(); err != nil {
+			return fmt.Errorf("webhooks: endpoint %v: %w", i+1, err)
+		}
+	}
+	switch c.Webhooks.Missed {
+	case "", MissedDrop, MissedDeliver:
+	default:
+		return fmt.Errorf("webhooks: unknown missed policy %q, expected drop or deliver", c.Webhooks.Missed)
+	}
+	if c.Webhooks.Attempts < 0 {
+		return errors.New("webhooks: attempts must not be negative")
+	}
+	return nil
+}
+
+func (w Webhook) validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q, expected http or https url", w.URL)
+	}
+	if len(w.On) == 0 {
+		return errors.New("on must list events, e.g. [\"at:maghrib\"]")
+	}
+	for _, event := range w.On {
+		if _, _, err := event.Parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r Reminder) validate() error {
+	if _, err := PrayerName(r.Prayer); err != nil {
+		return err
+	}
+	if r.Escalate == nil {
+		return nil
+	}
+	if r.Escalate.After <= 0 {
+		return errors.New("escalate after must be set, e.g. \"30m\"")
+	}
+	switch r.Escalate.Urgency {
+	case "", UrgencyLow, UrgencyNormal, UrgencyCritical:
+		return nil
+	}
+	return fmt.Errorf("unknown urgency %q, expected one of low, normal, critical", r.Escalate.Urgency)
+}
+
+// PrayerReminders returns reminders of prayer named @name, as in
+// @models.SortedPrayerNames or Sunrise. Without @Reminders, prayers in
+// @Prayers are notified at prayer time and @Before lead times
+func (n Notifications) PrayerReminders(name string) []Reminder {
+	var reminders []Reminder
+	if len(n.Reminders) > 0 {
+		for _, reminder := range n.Reminders {
+			if prayerName, err := PrayerName(reminder.Prayer); err == nil && prayerName == name {
+				reminders = append(reminders, reminder)
+			}
+		}
+		return reminders
+	}
+	if !slices.Contains(n.NotifiedPrayers(), name) {
+		return nil
+	}
+
+	reminders = append(reminders, Reminder{Prayer: name})
+	for _, before := range n.Before {
+		reminders = append(reminders, Reminder{Prayer: name, Before: before})
+	}
+	return reminders
+}
+
+// NotifiedPrayers returns names of prayers to notify at, as in @models.SortedPrayerNames
+func (n Notifications) NotifiedPrayers() []string {
+	if len(n.Prayers) == 0 {
+		return models.SortedPrayerNames
+	}
+	names := make([]string, 0, len(n.Prayers))
+	for _, name := range n.Prayers {
+		if prayerName, err := PrayerName(name); err == nil {
+			names = append(names, prayerName)
+		}
+	}
+	return names
+}
+
+// PrayerName matches @name case insensitively to a prayer name, or sunrise
+func PrayerName(name string) (string, error) {
+	for _, prayerName := range slices.Concat(models.SortedPrayerNames, []string{"Sunrise"}) {
+		if strings.EqualFold(strings.TrimSpace(name), prayerName) {
+			return prayerName, nil
+		}
+	}
+	return "", fmt.Errorf("unknown prayer %q, expected one of fajr, sunrise, dhuhr, asr, maghrib, isha", name)
+}
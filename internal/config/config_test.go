@@ -0,0 +1,271 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    Config
+		expectError bool
+	}{
+		{name: "Missing file"},
+		{
+			name:    "Notifications",
+			content: `{"notifications": {"prayers": ["fajr", "Maghrib"], "before": ["15m", "1h30m"]}}`,
+			expected: Config{Notifications: Notifications{
+				Prayers: []string{"fajr", "Maghrib"},
+				Before:  []Duration{Duration(15 * time.Minute), Duration(90 * time.Minute)},
+			}},
+		},
+		{name: "Unknown prayer", content: `{"notifications": {"prayers": ["duha"]}}`, expectError: true},
+		{name: "Duration without unit", content: `{"notifications": {"before": [15]}}`, expectError: true},
+		{name: "Negative duration", content: `{"notifications": {"before": ["-5m"]}}`, expectError: true},
+		{name: "Invalid json", content: `{"notifications":`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := Load(path)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNotifiedPrayers(t *testing.T) {
+	all := Notifications{}.NotifiedPrayers()
+	if len(all) != 5 || all[0] != "Fajr" {
+		t.Errorf("Expected all prayers by default but got %v", all)
+	}
+
+	some := Notifications{Prayers: []string{"ASR", " isha "}}.NotifiedPrayers()
+	if !reflect.DeepEqual(some, []string{"Asr", "Isha"}) {
+		t.Errorf("Expected [Asr Isha] but got %v", some)
+	}
+}
+
+func TestLoadReminders(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{name: "Reminders", content: `{"notifications": {"reminders": [{"prayer": "sunrise", "before": "30m", "escalate": {"after": "20m", "persistent": true}}]}}`},
+		{name: "Unknown prayer", content: `{"notifications": {"reminders": [{"prayer": "duha"}]}}`, expectError: true},
+		{name: "Escalation without delay", content: `{"notifications": {"reminders": [{"prayer": "asr", "escalate": {}}]}}`, expectError: true},
+		{name: "Unknown urgency", content: `{"notifications": {"reminders": [{"prayer": "asr", "escalate": {"after": "5m", "urgency": "loud"}}]}}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if hasError := err != nil; hasError != tt.expectError {
+				t.Errorf("Expected error %v but got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestPrayerReminders(t *testing.T) {
+	withoutReminders := Notifications{
+		Prayers: []string{"asr", "maghrib"},
+		Before:  []Duration{Duration(10 * time.Minute)},
+	}
+	withReminders := withoutReminders
+	withReminders.Reminders = []Reminder{
+		{Prayer: "Maghrib"},
+		{Prayer: "sunrise", Before: Duration(30 * time.Minute)},
+	}
+
+	tests := []struct {
+		name          string
+		notifications Notifications
+		prayer        string
+		expected      []Reminder
+	}{
+		{name: "Prayer not notified", notifications: withoutReminders, prayer: "Fajr"},
+		{name: "Prayer with lead time", notifications: withoutReminders, prayer: "Asr", expected: []Reminder{{Prayer: "Asr"}, {Prayer: "Asr", Before: Duration(10 * time.Minute)}}},
+		{name: "Sunrise without reminders", notifications: withoutReminders, prayer: "Sunrise"},
+		{name: "Sunrise reminder", notifications: withReminders, prayer: "Sunrise", expected: []Reminder{{Prayer: "sunrise", Before: Duration(30 * time.Minute)}}},
+		{name: "Reminders replace lead times", notifications: withReminders, prayer: "Maghrib", expected: []Reminder{{Prayer: "Maghrib"}}},
+		{name: "Prayer without reminder", notifications: withReminders, prayer: "Asr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.notifications.PrayerReminders(tt.prayer)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestHookEventParse(t *testing.T) {
+	tests := []struct {
+		event          HookEvent
+		expectedPrayer string
+		expectedOffset time.Duration
+		expectError    bool
+	}{
+		{event: "at:maghrib", expectedPrayer: "Maghrib"},
+		{event: "before:asr:10m", expectedPrayer: "Asr", expectedOffset: -10 * time.Minute},
+		{event: "After:Isha:1h30m", expectedPrayer: "Isha", expectedOffset: 90 * time.Minute},
+		{event: "before:sunrise:30m", expectedPrayer: "Sunrise", expectedOffset: -30 * time.Minute},
+		{event: "at:maghrib:5m", expectError: true},
+		{event: "before:asr", expectError: true},
+		{event: "before:asr:-5m", expectError: true},
+		{event: "during:asr:5m", expectError: true},
+		{event: "at:duha", expectError: true},
+		{event: "maghrib", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.event), func(t *testing.T) {
+			prayer, offset, err := tt.event.Parse()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got %v %v", prayer, offset)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if prayer != tt.expectedPrayer || offset != tt.expectedOffset {
+				t.Errorf("Expected %v %v but got %v %v", tt.expectedPrayer, tt.expectedOffset, prayer, offset)
+			}
+		})
+	}
+}
+
+func TestAdhanMuted(t *testing.T) {
+	adhan := Adhan{Mute: []MuteRange{
+		{From: ClockTime(22 * 60), To: ClockTime(6 * 60)},
+		{From: ClockTime(12 * 60), To: ClockTime(14 * 60), Days: []string{"fri"}},
+	}}
+	// 2026-03-20 is a friday
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected bool
+	}{
+		{name: "Before night range", time: at(20, 21, 59)},
+		{name: "Night range start", time: at(20, 22, 0), expected: true},
+		{name: "Night range after midnight", time: at(21, 5, 59), expected: true},
+		{name: "Night range end", time: at(21, 6, 0)},
+		{name: "Friday range", time: at(20, 12, 30), expected: true},
+		{name: "Friday range on saturday", time: at(21, 12, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := adhan.Muted(tt.time); result != tt.expected {
+				t.Errorf("Expected muted %v at %v but got %v", tt.expected, tt.time, result)
+			}
+		})
+	}
+}
+
+func TestAdhanPrayerFile(t *testing.T) {
+	adhan := Adhan{File: "adhan.mp3", FajrFile: "fajr.mp3", Prayers: map[string]bool{"Dhuhr": false, "asr": true}}
+
+	tests := map[string]string{"Fajr": "fajr.mp3", "Dhuhr": "", "Asr": "adhan.mp3", "Isha": "adhan.mp3"}
+	for prayer, expected := range tests {
+		if result := adhan.PrayerFile(prayer); result != expected {
+			t.Errorf("Expected %q for %v but got %q", expected, prayer, result)
+		}
+	}
+	if result := (Adhan{FajrFile: "fajr.mp3"}).PrayerFile("Asr"); result != "" {
+		t.Errorf("Expected only fajr adhan but got %q for asr", result)
+	}
+}
+
+func TestLoadAdhan(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{name: "Adhan", content: `{"adhan": {"file": "a.mp3", "prayers": {"fajr": false}, "mute": [{"from": "22:00", "to": "06:00", "days": ["Friday"]}]}}`},
+		{name: "Unknown prayer", content: `{"adhan": {"prayers": {"duha": true}}}`, expectError: true},
+		{name: "Invalid time", content: `{"adhan": {"mute": [{"from": "25:00", "to": "06:00"}]}}`, expectError: true},
+		{name: "Unknown day", content: `{"adhan": {"mute": [{"from": "22:00", "to": "06:00", "days": ["someday"]}]}}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if hasError := err != nil; hasError != tt.expectError {
+				t.Errorf("Expected error %v but got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestLoadWebhooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{name: "Webhooks", content: `{"webhooks": {"location": "Beirut", "missed": "deliver", "maxAge": "2h", "endpoints": [{"url": "https://example.com/hook", "secret": "s", "on": ["at:maghrib", "before:asr:10m"]}]}}`},
+		{name: "Invalid url", content: `{"webhooks": {"endpoints": [{"url": "example.com/hook", "on": ["at:maghrib"]}]}}`, expectError: true},
+		{name: "No events", content: `{"webhooks": {"endpoints": [{"url": "https://example.com/hook"}]}}`, expectError: true},
+		{name: "Invalid event", content: `{"webhooks": {"endpoints": [{"url": "https://example.com/hook", "on": ["at:duha"]}]}}`, expectError: true},
+		{name: "Unknown missed policy", content: `{"webhooks": {"missed": "queue"}}`, expectError: true},
+		{name: "Negative attempts", content: `{"webhooks": {"attempts": -1}}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if hasError := err != nil; hasError != tt.expectError {
+				t.Errorf("Expected error %v but got %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// adhanTrigger names triggers of adhan
+const adhanTrigger = "adhan"
+
+// defaultPlayers are tried in order when config sets no player
+var defaultPlayers = [][]string{
+	{"mpv", "--no-video", "--really-quiet"},
+	{"paplay"},
+	{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"},
+}
+
+// AdhanHandler plays adhan at prayer times as set in config adhan section.
+// One adhan plays at a time, a trigger coming while one plays is skipped
+type AdhanHandler struct {
+	// PIDFile keeps pid and start time of player while it plays, so other
+	// processes can stop it
+	PIDFile string
+
+	Log *log.Logger
+
+	mu      sync.Mutex
+	playing *exec.Cmd
+	done    sync.WaitGroup
+}
+
+func (h *AdhanHandler) Triggers(cfg config.Config) []Trigger {
+	var triggers []Trigger
+	for _, prayer := range models.SortedPrayerNames {
+		if cfg.Adhan.PrayerFile(prayer) != "" {
+			triggers = append(triggers, Trigger{Prayer: prayer, Name: adhanTrigger})
+		}
+	}
+	return triggers
+}
+
+// Handle starts adhan of @event unless it is missed or muted, and returns
+func (h *AdhanHandler) Handle(cfg config.Config, event Event) {
+	if event.Name != adhanTrigger {
+		return
+	}
+	switch {
+	case event.Missed:
+		h.Log.Printf("skipped missed adhan of %v", event.Prayer)
+		return
+	case cfg.Adhan.Muted(event.At):
+		h.Log.Printf("skipped adhan of %v, muted at %v", event.Prayer, event.At.Format("15:04"))
+		return
+	}
+
+	args, err := playerCommand(cfg.Adhan.Player, cfg.Adhan.PrayerFile(event.Prayer))
+	if err != nil {
+		h.Log.Printf("error: adhan of %v: %v", event.Prayer, err)
+		return
+	}
+	h.play(event.Prayer, args)
+}
+
+func (h *AdhanHandler) play(prayer string, args []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.playing != nil {
+		h.Log.Printf("skipped adhan of %v, another adhan is playing", prayer)
+		return
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		h.Log.Printf("error: adhan of %v: %v", prayer, err)
+		return
+	}
+	h.playing = cmd
+	if h.PIDFile != "" {
+		if err := writePIDFile(h.PIDFile, cmd.Process.Pid); err != nil {
+			h.Log.Printf("error: adhan pid file: %v", err)
+		}
+	}
+	h.Log.Printf("playing adhan of %v", prayer)
+
+	h.done.Add(1)
+	go func() {
+		defer h.done.Done()
+		start := time.Now()
+		err := cmd.Wait()
+
+		h.mu.Lock()
+		h.playing = nil
+		if h.PIDFile != "" {
+			os.Remove(h.PIDFile)
+		}
+		h.mu.Unlock()
+
+		if err != nil {
+			h.Log.Printf("adhan of %v stopped after %v: %v", prayer, time.Since(start).Round(time.Second), err)
+		} else {
+			h.Log.Printf("adhan of %v finished", prayer)
+		}
+	}()
+}
+
+// Stop stops adhan playing in this process, returning false if none is
+func (h *AdhanHandler) Stop() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.playing == nil {
+		return false
+	}
+	return h.playing.Process.Kill() == nil
+}
+
+// Wait blocks until playing adhan finishes
+func (h *AdhanHandler) Wait() {
+	h.done.Wait()
+}
+
+// StopAdhan stops adhan player whose pid is in @pidFile, returning false if
+// none is playing. Process is only killed if it started when player did, a
+// pid file left behind may name another process by now
+func StopAdhan(pidFile string) (bool, error) {
+	data, err := os.ReadFile(pidFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	pidStr, start, found := strings.Cut(strings.TrimSpace(string(data)), "\n")
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || !found {
+		return false, fmt.Errorf("invalid pid file %v", pidFile)
+	}
+
+	if current, err := processStartTime(pid); err != nil || current != start {
+		// player is gone, and its pid file was not removed
+		os.Remove(pidFile)
+		return false, nil
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false, err
+	}
+	if err := process.Kill(); err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// writePIDFile writes @pid and start time of its process to @path
+func writePIDFile(path string, pid int) error {
+	start, err := processStartTime(pid)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(fmt.Sprintf("%v\n%v\n", pid, start)), 0644)
+}
+
+// playerCommand returns command playing @file with @player, or with first
+// installed default player
+func playerCommand(player []string, file string) ([]string, error) {
+	if len(player) == 0 {
+		for _, candidate := range defaultPlayers {
+			if _, err := exec.LookPath(candidate[0]); err == nil {
+				player = candidate
+				break
+			}
+		}
+	}
+	if len(player) == 0 {
+		return nil, errors.New("no audio player found, install mpv, paplay or ffplay, or set adhan player")
+	}
+
+	i := slices.Index(player, "{file}")
+	if i < 0 {
+		return append(slices.Clone(player), file), nil
+	}
+	args := slices.Clone(player)
+	args[i] = file
+	return args, nil
+}
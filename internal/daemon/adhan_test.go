@@ -0,0 +1,132 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// stubPlayer writes a player recording its args to a file, then sleeping
+// @seconds. It returns player and file of invocations
+func stubPlayer(t *testing.T, seconds float64) (string, string) {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "plays")
+	return writeStubScript(t, fmt.Sprintf("echo \"$@\" >> %v\nsleep %v\n", out, seconds)), out
+}
+
+func TestAdhanHandler(t *testing.T) {
+	player, out := stubPlayer(t, 0)
+	adhan := config.Adhan{
+		File:     "adhan.mp3",
+		FajrFile: "fajr.mp3",
+		Player:   []string{player, "--volume=70", "{file}"},
+		Prayers:  map[string]bool{"dhuhr": false, "asr": true},
+		Mute:     []config.MuteRange{{From: config.ClockTime(17 * 60), To: config.ClockTime(18*60 + 30)}},
+	}
+
+	handler := &AdhanHandler{PIDFile: filepath.Join(t.TempDir(), "adhan.pid"), Log: log.New(io.Discard, "", 0)}
+	clock := &fakeClock{now: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 20, 23, 0, 0, 0, time.UTC)}
+	// fake clock does not wait for players, each is waited for at its trigger
+	waiting := &recordingHandler{onHandle: handler.Wait}
+	for _, prayer := range []string{"Fajr", "Asr", "Isha"} {
+		waiting.triggers = append(waiting.triggers, Trigger{Prayer: prayer, Offset: time.Second})
+	}
+	runTestScheduler(&Scheduler{
+		Load: func() (domain.PrayerTimesRepo, config.Config, error) {
+			return testRepo{}, config.Config{Adhan: adhan}, nil
+		},
+		Handlers: []Handler{handler, waiting},
+	}, clock)
+	handler.Wait()
+
+	// dhuhr is off and maghrib muted
+	expected := []string{"--volume=70 fajr.mp3", "--volume=70 adhan.mp3", "--volume=70 adhan.mp3"}
+	if plays := readLines(t, out); !reflect.DeepEqual(plays, expected) {
+		t.Errorf("Expected %q but got %q", expected, plays)
+	}
+	if _, err := os.Stat(handler.PIDFile); !os.IsNotExist(err) {
+		t.Errorf("Expected pid file to be removed after playing, got %v", err)
+	}
+}
+
+func TestAdhanHandlerOverlapAndStop(t *testing.T) {
+	player, out := stubPlayer(t, 30)
+	cfg := config.Config{Adhan: config.Adhan{File: "adhan.mp3", Player: []string{player}}}
+	handler := &AdhanHandler{PIDFile: filepath.Join(t.TempDir(), "adhan.pid"), Log: log.New(io.Discard, "", 0)}
+	schedule, _ := testRepo{}.GetDailyPrayerSchedule(time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	handler.Handle(cfg, Event{Trigger: Trigger{Prayer: "Maghrib", Name: adhanTrigger}, Schedule: schedule, At: schedule.Prayers[3].Time})
+	handler.Handle(cfg, Event{Trigger: Trigger{Prayer: "Isha", Name: adhanTrigger}, Schedule: schedule, At: schedule.Prayers[4].Time})
+
+	// wait for player to start before stopping it
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(out); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	start := time.Now()
+	stopped, err := StopAdhan(handler.PIDFile)
+	if err != nil || !stopped {
+		t.Fatalf("Expected adhan to be stopped but got %v, %v", stopped, err)
+	}
+	handler.Wait()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected player to stop at once but it took %v", elapsed)
+	}
+
+	if plays := readLines(t, out); !reflect.DeepEqual(plays, []string{"adhan.mp3"}) {
+		t.Errorf("Expected overlapping adhan to be skipped but got %q", plays)
+	}
+	if stopped, err := StopAdhan(handler.PIDFile); stopped || err != nil {
+		t.Errorf("Expected nothing to stop but got %v, %v", stopped, err)
+	}
+	if handler.Stop() {
+		t.Errorf("Expected nothing to stop")
+	}
+}
+
+func TestStopAdhanVerifiesProcess(t *testing.T) {
+	player, _ := stubPlayer(t, 30)
+	cmd := exec.Command(player)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	// pid file left by a player that started at another time
+	pidFile := filepath.Join(t.TempDir(), "adhan.pid")
+	if err := os.WriteFile(pidFile, []byte(fmt.Sprintf("%v\n1\n", cmd.Process.Pid)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if stopped, err := StopAdhan(pidFile); stopped || err != nil {
+		t.Errorf("Expected process of other start time not to be stopped but got %v, %v", stopped, err)
+	}
+	if _, err := os.Stat(pidFile); !os.IsNotExist(err) {
+		t.Errorf("Expected stale pid file to be removed, got %v", err)
+	}
+	if err := cmd.Process.Signal(syscall.Signal(0)); err != nil {
+		t.Errorf("Expected process to be running but got %v", err)
+	}
+
+	if err := writePIDFile(pidFile, cmd.Process.Pid); err != nil {
+		t.Fatal(err)
+	}
+	if stopped, err := StopAdhan(pidFile); !stopped || err != nil {
+		t.Errorf("Expected process to be stopped but got %v, %v", stopped, err)
+	}
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// Trigger is a moment relative to a prayer a @Handler wants to act at
+type Trigger struct {
+	// Prayer name as in @models.SortedPrayerNames, or Sunrise
+	Prayer string
+
+	// Offset from prayer time, negative before it and positive after it
+	Offset time.Duration
+
+	// Name tells apart triggers of the same handler, handlers set it freely
+	Name string
+}
+
+// Event is a @Trigger placed on a day
+type Event struct {
+	Trigger
+
+	// At is when event fires, prayer time plus offset
+	At time.Time
+
+	PrayerTime time.Time
+	Schedule   domain.DailyPrayerSchedule
+
+	// Missed is set when event fires late, e.g. after suspend, by more than
+	// scheduler grace. Handlers decide whether a missed event is still useful
+	Missed bool
+}
+
+// plannedEvent is an event and the handler to call with it
+type plannedEvent struct {
+	Event
+	handler int
+}
+
+// planEvents places @triggers of each handler on @schedules, sorted by time
+func planEvents(schedules []domain.DailyPrayerSchedule, triggers [][]Trigger) []plannedEvent {
+	var events []plannedEvent
+	for _, schedule := range schedules {
+		for handler, handlerTriggers := range triggers {
+			for _, trigger := range handlerTriggers {
+				prayerTime, ok := prayerTimeOf(schedule, trigger.Prayer)
+				if !ok {
+					continue
+				}
+				events = append(events, plannedEvent{
+					Event: Event{
+						Trigger:    trigger,
+						At:         prayerTime.Add(trigger.Offset),
+						PrayerTime: prayerTime,
+						Schedule:   schedule,
+					},
+					handler: handler,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b plannedEvent) int {
+		return a.At.Compare(b.At)
+	})
+	return events
+}
+
+func prayerTimeOf(schedule domain.DailyPrayerSchedule, name string) (time.Time, bool) {
+	if name == "Sunrise" {
+		return schedule.Sunrise, !schedule.Sunrise.IsZero()
+	}
+	return domain.FindPrayer(schedule.Prayers, name)
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// handlerTriggers returns triggers of each of @handlers, given @cfg
+func handlerTriggers(handlers []Handler, cfg config.Config) [][]Trigger {
+	triggers := make([][]Trigger, len(handlers))
+	for i, handler := range handlers {
+		triggers[i] = handler.Triggers(cfg)
+	}
+	return triggers
+}
+
+// planRange plans events of @handlers firing from @from, inclusive, to @to
+func planRange(repo domain.PrayerTimesRepo, cfg config.Config, handlers []Handler, from time.Time, to time.Time) ([]plannedEvent, error) {
+	var schedules []domain.DailyPrayerSchedule
+	// day before and after too, for triggers after isha running past midnight
+	// or long before fajr. They may be missing, e.g. next year not published
+	first, last := startOfDay(from).AddDate(0, 0, -1), startOfDay(to.Add(-1)).AddDate(0, 0, 1)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		schedule, err := repo.GetDailyPrayerSchedule(day)
+		if err != nil {
+			if day.Equal(first) || day.Equal(last) {
+				continue
+			}
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	var events []plannedEvent
+	for _, event := range planEvents(schedules, handlerTriggers(handlers, cfg)) {
+		if !event.At.Before(from) && event.At.Before(to) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// EventTimes returns minutes from @from to @to that events of @handlers fire
+// at, for schedulers outside daemon like systemd timers to call @Fire at
+func EventTimes(repo domain.PrayerTimesRepo, cfg config.Config, handlers []Handler, from time.Time, to time.Time) ([]time.Time, error) {
+	events, err := planRange(repo, cfg, handlers, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	for _, event := range events {
+		at := event.At.Truncate(time.Minute)
+		if len(times) == 0 || !times[len(times)-1].Equal(at) {
+			times = append(times, at)
+		}
+	}
+	return times, nil
+}
+
+// Fire handles events of @handlers firing in minute of @at, marking them
+// missed if @now is more than @grace late. It returns number of events
+func Fire(repo domain.PrayerTimesRepo, cfg config.Config, handlers []Handler, at time.Time, now time.Time, grace time.Duration) (int, error) {
+	at = at.Truncate(time.Minute)
+	events, err := planRange(repo, cfg, handlers, at, at.Add(time.Minute))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		event.Missed = now.Sub(event.At) > grace
+		handlers[event.handler].Handle(cfg, event.Event)
+	}
+	return len(events), nil
+}
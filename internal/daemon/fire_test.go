@@ -0,0 +1,83 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+)
+
+func TestEventTimes(t *testing.T) {
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	handlers := []Handler{
+		&recordingHandler{triggers: []Trigger{
+			{Prayer: "Fajr", Offset: -15 * time.Minute},
+			{Prayer: "Asr"},
+			{Prayer: "Isha", Offset: 5 * time.Hour},
+		}},
+		&recordingHandler{triggers: []Trigger{{Prayer: "Asr", Offset: 30 * time.Second}}},
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected []time.Time
+	}{
+		{name: "Rest of the day", from: at(20, 10, 0), to: at(21, 0, 0), expected: []time.Time{at(20, 15, 0)}},
+		{name: "Two days", from: at(20, 10, 0), to: at(22, 0, 0), expected: []time.Time{at(20, 15, 0), at(21, 0, 0), at(21, 4, 45), at(21, 15, 0)}},
+		{name: "From event time", from: at(20, 15, 0), to: at(20, 16, 0), expected: []time.Time{at(20, 15, 0)}},
+		{name: "No events", from: at(20, 16, 0), to: at(20, 23, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EventTimes(testRepo{}, config.Config{}, handlers, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFire(t *testing.T) {
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		now      time.Time
+		expected []string
+	}{
+		{name: "On time", at: at(20, 15, 0), now: at(20, 15, 1), expected: []string{"Asr 0s 20 15:00", "Asr 30s 20 15:00"}},
+		{name: "Late", at: at(20, 15, 0), now: at(20, 15, 10), expected: []string{"Asr 0s 20 15:00 missed", "Asr 30s 20 15:00 missed"}},
+		{name: "Trigger of day before", at: at(21, 0, 0), now: at(21, 0, 0), expected: []string{"Isha 5h0m0s 21 00:00"}},
+		{name: "No events", at: at(20, 15, 1), now: at(20, 15, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &recordingHandler{triggers: []Trigger{
+				{Prayer: "Asr"},
+				{Prayer: "Asr", Offset: 30 * time.Second},
+				{Prayer: "Isha", Offset: 5 * time.Hour},
+			}}
+
+			count, err := Fire(testRepo{}, config.Config{}, []Handler{handler}, tt.at, tt.now, 2*time.Minute)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if count != len(tt.expected) || !reflect.DeepEqual(handler.events, tt.expected) {
+				t.Errorf("Expected %v but got %v, count %v", tt.expected, handler.events, count)
+			}
+		})
+	}
+}
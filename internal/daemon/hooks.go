@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+)
+
+// hookTrigger names triggers of hooks, followed by index of hook in config
+const hookTrigger = "hook"
+
+// maxHookOutput caps output of a hook kept in log
+const maxHookOutput = 4 << 10
+
+// HookHandler runs commands of config hooks section at their events, each
+// in its own goroutine with a timeout, at most hooks concurrency at once.
+// Runs, exit status and output go to hooks log file
+type HookHandler struct {
+	// Log receives errors, and hook runs if hooks log can not be opened
+	Log *log.Logger
+
+	slots     chan struct{}
+	slotsSize int
+	running   sync.WaitGroup
+}
+
+func (h *HookHandler) Triggers(cfg config.Config) []Trigger {
+	var triggers []Trigger
+	for i, hook := range cfg.Hooks.Commands {
+		prayer, offset, err := hook.On.Parse()
+		if err != nil {
+			continue
+		}
+		triggers = append(triggers, Trigger{Prayer: prayer, Offset: offset, Name: fmt.Sprintf("%v %v", hookTrigger, i)})
+	}
+	return triggers
+}
+
+// Handle starts hook of @event and returns, skipping missed events
+func (h *HookHandler) Handle(cfg config.Config, event Event) {
+	var i int
+	if _, err := fmt.Sscanf(event.Name, hookTrigger+" %d", &i); err != nil || i >= len(cfg.Hooks.Commands) {
+		return
+	}
+	hook := cfg.Hooks.Commands[i]
+	if event.Missed {
+		h.Log.Printf("skipped missed hook %v: %v", hook.On, hook.Run)
+		return
+	}
+
+	if size := cfg.Hooks.MaxConcurrency(); h.slots == nil || h.slotsSize != size {
+		// hooks still running release slots of previous channel
+		h.slots, h.slotsSize = make(chan struct{}, size), size
+	}
+	slots := h.slots
+	timeout := cfg.Hooks.HookTimeout(hook)
+	logPath, err := cfg.Hooks.LogPath()
+	if err != nil {
+		h.Log.Printf("error: hooks log: %v", err)
+	}
+
+	h.running.Add(1)
+	go func() {
+		defer h.running.Done()
+		slots <- struct{}{}
+		defer func() { <-slots }()
+		h.run(logPath, hook, event, timeout)
+	}()
+}
+
+// Wait blocks until running and waiting hooks finish
+func (h *HookHandler) Wait() {
+	h.running.Wait()
+}
+
+// run runs @hook logging to @logPath, opened on each run so log can be
+// rotated, or to daemon log if it can not be opened
+func (h *HookHandler) run(logPath string, hook config.Hook, event Event, timeout time.Duration) {
+	logger := h.Log
+	if logPath != "" {
+		file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			h.Log.Printf("error: hooks log: %v", err)
+		} else {
+			defer file.Close()
+			logger = log.New(file, "", log.LstdFlags)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, hook.Run)
+	killProcessGroup(cmd)
+	cmd.Env = append(os.Environ(), hookEnv(hook, event)...)
+	// do not wait for children still holding output after shell is killed
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	status := "ok"
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		status = fmt.Sprintf("timed out after %v", timeout)
+	case err != nil:
+		status = err.Error()
+	}
+
+	logger.Printf("hook %v: %v: %v in %v", hook.On, hook.Run, status, time.Since(start).Round(time.Millisecond))
+	if output := strings.TrimSpace(string(output)); output != "" {
+		if len(output) > maxHookOutput {
+			output = output[:maxHookOutput] + "..."
+		}
+		logger.Printf("hook %v output:\n%v", hook.On, output)
+	}
+}
+
+// hookEnv describes @event to hook commands
+func hookEnv(hook config.Hook, event Event) []string {
+	env := []string{
+		"PRAYER_NAME=" + event.Prayer,
+		"PRAYER_TIME=" + event.PrayerTime.Format(time.RFC3339),
+		"PRAYER_EVENT=" + strings.ToLower(strings.TrimSpace(string(hook.On))),
+		"HIJRI_DATE=",
+	}
+	if !event.Schedule.Hijri.IsZero() {
+		env[3] += event.Schedule.Hijri.String()
+	}
+	return env
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
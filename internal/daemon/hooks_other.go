@@ -0,0 +1,8 @@
+//go:build !unix
+
+package daemon
+
+import "os/exec"
+
+// killProcessGroup keeps default of killing only @cmd itself
+func killProcessGroup(cmd *exec.Cmd) {}
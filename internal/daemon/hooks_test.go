@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// writeStubScript writes shell script @body to a temp dir, returning its path
+func writeStubScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub scripts need sh")
+	}
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+// runHooks runs scheduler with @hooks from 2026-03-20 10:00 to 20:00
+func runHooks(t *testing.T, hooks config.Hooks) *HookHandler {
+	t.Helper()
+	if hooks.Log == "" {
+		hooks.Log = filepath.Join(t.TempDir(), "hooks.log")
+	}
+	handler := &HookHandler{Log: log.New(io.Discard, "", 0)}
+	clock := &fakeClock{now: time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 20, 20, 0, 0, 0, time.UTC)}
+	runTestScheduler(&Scheduler{
+		Load: func() (domain.PrayerTimesRepo, config.Config, error) {
+			return testRepo{}, config.Config{Hooks: hooks}, nil
+		},
+		Handlers: []Handler{handler},
+	}, clock)
+	handler.Wait()
+	return handler
+}
+
+func TestHookEnv(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	script := writeStubScript(t, fmt.Sprintf(`echo "$PRAYER_NAME|$PRAYER_TIME|$PRAYER_EVENT|$HIJRI_DATE" >> %v`, out))
+
+	runHooks(t, config.Hooks{Concurrency: 1, Commands: []config.Hook{
+		{On: "before:asr:10m", Run: script},
+		{On: "at:Maghrib", Run: script},
+		{On: "after:isha:5m", Run: script},
+	}})
+
+	// fake clock does not wait for hooks, so they may run in any order
+	expected := []string{
+		"Asr|2026-03-20T15:00:00Z|before:asr:10m|1447-10-01",
+		"Isha|2026-03-20T19:00:00Z|after:isha:5m|1447-10-01",
+		"Maghrib|2026-03-20T18:00:00Z|at:maghrib|1447-10-01",
+	}
+	lines := readLines(t, out)
+	slices.Sort(lines)
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %q but got %q", expected, lines)
+	}
+}
+
+func TestHookConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		expected    []string
+	}{
+		{name: "One at a time", concurrency: 1, expected: []string{"start", "end", "start", "end", "start", "end"}},
+		{name: "All at once", concurrency: 3, expected: []string{"start", "start", "start", "end", "end", "end"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// each hook waits until as many hooks as may run at once started,
+			// giving up after 5s
+			out := filepath.Join(t.TempDir(), "out")
+			script := writeStubScript(t, fmt.Sprintf(`echo start >> %[1]v
+i=0
+while [ "$(grep -c start %[1]v)" -lt %[2]v ] && [ $i -lt 100 ]; do
+	sleep 0.05
+	i=$((i+1))
+done
+echo end >> %[1]v
+`, out, tt.concurrency))
+
+			// all three at asr
+			runHooks(t, config.Hooks{Concurrency: tt.concurrency, Commands: []config.Hook{
+				{On: "at:asr", Run: script},
+				{On: "at:asr", Run: script},
+				{On: "at:asr", Run: script},
+			}})
+
+			if lines := readLines(t, out); !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected %q but got %q", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestHookTimeoutAndLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "hooks.log")
+	slow := writeStubScript(t, "echo working\nsleep 5\necho done\n")
+
+	start := time.Now()
+	runHooks(t, config.Hooks{Log: logPath, Commands: []config.Hook{
+		{On: "at:asr", Run: slow, Timeout: config.Duration(200 * time.Millisecond)},
+		{On: "at:maghrib", Run: "exit 3"},
+	}})
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected slow hook to be killed but run took %v", elapsed)
+	}
+
+	hookLog := strings.Join(readLines(t, logPath), "\n")
+	for _, expected := range []string{"hook at:asr: " + slow + ": timed out after 200ms", "working", "hook at:maghrib: exit 3: exit status 3"} {
+		if !strings.Contains(hookLog, expected) {
+			t.Errorf("Expected hooks log to contain %q, got %q", expected, hookLog)
+		}
+	}
+	if strings.Contains(hookLog, "done") {
+		t.Errorf("Expected slow hook not to finish, got %q", hookLog)
+	}
+}
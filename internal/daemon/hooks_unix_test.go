@@ -0,0 +1,54 @@
+//go:build unix
+
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+)
+
+// processGone returns true if process @pid exited, zombies count as exited
+func processGone(t *testing.T, pid int) bool {
+	t.Helper()
+	out, err := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	state := strings.TrimSpace(string(out))
+	return err != nil || state == "" || strings.HasPrefix(state, "Z")
+}
+
+func TestHookTimeoutKillsChildren(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	script := writeStubScript(t, "sleep 30 &\necho $! > "+pidFile+"\nwait\n")
+
+	runHooks(t, config.Hooks{Commands: []config.Hook{
+		{On: "at:asr", Run: script, Timeout: config.Duration(200 * time.Millisecond)},
+	}})
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if process, err := os.FindProcess(pid); err == nil {
+			process.Kill()
+		}
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !processGone(t, pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected child %v of timed out hook to be killed", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package daemon
+
+import "os"
+
+// lockFile does not lock, processes sharing @file may overwrite changes of
+// each other
+func lockFile(file *os.File) error {
+	return nil
+}
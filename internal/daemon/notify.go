@@ -0,0 +1,173 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+	"github.com/mabd-dev/prayer-times-cli/internal/ui"
+)
+
+// appName is shown by notification servers as sender
+const appName = "prayers"
+
+var errNoNotifier = errors.New("no notification service found, install notify-send or run a notification daemon")
+
+// Notification is a desktop notification
+type Notification struct {
+	Title string
+	Body  string
+
+	// Urgency is normal if empty
+	Urgency config.Urgency
+
+	// Persistent notification stays until user dismisses it
+	Persistent bool
+}
+
+// Notifier shows desktop notifications
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// NotifySend shows notifications by running notify-send
+type NotifySend struct{}
+
+func (NotifySend) Notify(notification Notification) error {
+	args := []string{"--app-name", appName}
+	if notification.Urgency != "" {
+		args = append(args, "--urgency", string(notification.Urgency))
+	}
+	if notification.Persistent {
+		args = append(args, "--expire-time", "0")
+	}
+	args = append(args, notification.Title, notification.Body)
+	return exec.Command("notify-send", args...).Run()
+}
+
+// escalationTrigger names triggers of escalations, followed by index of
+// reminder in prayer reminders
+const escalationTrigger = "escalate"
+
+// NotificationHandler notifies at reminders of each prayer as set in config
+// notifications section, escalating if prayer is not logged in time
+type NotificationHandler struct {
+	Notifier Notifier
+
+	// PrayerLog is checked before escalating, escalations are off if it is nil
+	PrayerLog storage.PrayerLogStorage
+
+	Log *log.Logger
+}
+
+func (h *NotificationHandler) Triggers(cfg config.Config) []Trigger {
+	if cfg.Notifications.Disabled {
+		return nil
+	}
+
+	var triggers []Trigger
+	for _, prayer := range slices.Concat(models.SortedPrayerNames, []string{"Sunrise"}) {
+		for i, reminder := range cfg.Notifications.PrayerReminders(prayer) {
+			offset := -time.Duration(reminder.Before)
+			triggers = append(triggers, Trigger{Prayer: prayer, Offset: offset})
+			if reminder.Escalate != nil && h.PrayerLog != nil {
+				escalation := offset + time.Duration(reminder.Escalate.After)
+				if prayer == loggedPrayer(prayer) {
+					// prayer can not be logged before its time, fajr of a
+					// sunrise reminder is logged before sunrise
+					escalation = max(escalation, 0)
+				}
+				triggers = append(triggers, Trigger{
+					Prayer: prayer,
+					Offset: escalation,
+					Name:   fmt.Sprintf("%v %v", escalationTrigger, i),
+				})
+			}
+		}
+	}
+	return triggers
+}
+
+// Handle skips missed events, a late reminder is only noise
+func (h *NotificationHandler) Handle(cfg config.Config, event Event) {
+	if event.Missed {
+		h.Log.Printf("skipped missed notification of %v at %v", event.Prayer, event.At.Format(time.DateTime))
+		return
+	}
+
+	notification := prayerNotification(event)
+	var i int
+	if _, err := fmt.Sscanf(event.Name, escalationTrigger+" %d", &i); err == nil {
+		reminders := cfg.Notifications.PrayerReminders(event.Prayer)
+		if i >= len(reminders) || reminders[i].Escalate == nil {
+			return
+		}
+		prayed, err := h.prayed(event)
+		if err != nil {
+			h.Log.Printf("error: read prayer log: %v", err)
+		}
+		if prayed {
+			return
+		}
+		notification = escalationNotification(event, *reminders[i].Escalate)
+	}
+
+	if err := h.Notifier.Notify(notification); err != nil {
+		h.Log.Printf("error: notify %v: %v", event.Prayer, err)
+	}
+}
+
+// prayed checks prayer log, read again each time as other commands write it
+func (h *NotificationHandler) prayed(event Event) (bool, error) {
+	prayerLog, err := domain.LoadPrayerLog(h.PrayerLog)
+	if err != nil {
+		return false, err
+	}
+	return prayerLog.Prayed(event.Schedule.Date, loggedPrayer(event.Prayer)), nil
+}
+
+// loggedPrayer is prayer logged for reminders of @name, fajr for sunrise
+func loggedPrayer(name string) string {
+	if name == "Sunrise" {
+		return models.SortedPrayerNames[0]
+	}
+	return name
+}
+
+func escalationNotification(event Event, escalation config.Escalation) Notification {
+	notification := prayerNotification(event)
+	notification.Title = fmt.Sprintf("%v not prayed yet", loggedPrayer(event.Prayer))
+	notification.Urgency = escalation.Urgency
+	if notification.Urgency == "" {
+		notification.Urgency = config.UrgencyCritical
+	}
+	notification.Persistent = escalation.Persistent
+	return notification
+}
+
+func prayerNotification(event Event) Notification {
+	title := fmt.Sprintf("%v time", event.Prayer)
+	if event.Offset < 0 {
+		title = fmt.Sprintf("%v in %v", event.Prayer, ui.FormatDurationShort(-event.Offset))
+	}
+
+	body := []string{fmt.Sprintf("%v at %v", event.Prayer, event.PrayerTime.Format(ui.TimeLayout))}
+	if event.Prayer == "Sunrise" {
+		body[0] = fmt.Sprintf("Fajr time ends at %v", event.PrayerTime.Format(ui.TimeLayout))
+	}
+	if hijri := event.Schedule.Hijri.Format(ui.HijriLanguage); hijri != "" {
+		body = append(body, hijri)
+	}
+	if !event.Schedule.Event.IsZero() {
+		body = append(body, event.Schedule.Event.Name(ui.HijriLanguage))
+	}
+	return Notification{Title: title, Body: strings.Join(body, "\n")}
+}
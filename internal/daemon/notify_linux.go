@@ -0,0 +1,61 @@
+//go:build linux
+
+package daemon
+
+import (
+	"os/exec"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+)
+
+// dbusUrgencies are urgency hint values of freedesktop notifications spec
+var dbusUrgencies = map[config.Urgency]byte{
+	config.UrgencyLow:      0,
+	config.UrgencyNormal:   1,
+	config.UrgencyCritical: 2,
+}
+
+// DBusNotifier shows notifications through freedesktop notifications
+// interface on session bus
+type DBusNotifier struct {
+	conn *dbus.Conn
+}
+
+func (n *DBusNotifier) Notify(notification Notification) error {
+	hints := map[string]dbus.Variant{}
+	if urgency, ok := dbusUrgencies[notification.Urgency]; ok {
+		hints["urgency"] = dbus.MakeVariant(urgency)
+	}
+	// -1 lets server decide, 0 never expires
+	expireTimeout := int32(-1)
+	if notification.Persistent {
+		expireTimeout = 0
+		hints["resident"] = dbus.MakeVariant(true)
+	}
+
+	obj := n.conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+		appName,
+		uint32(0),
+		"",
+		notification.Title,
+		notification.Body,
+		[]string{},
+		hints,
+		expireTimeout,
+	)
+	return call.Err
+}
+
+// NewNotifier returns notifier on session bus, falling back to notify-send
+// when there is no session bus
+func NewNotifier() (Notifier, error) {
+	if conn, err := dbus.ConnectSessionBus(); err == nil {
+		return &DBusNotifier{conn: conn}, nil
+	}
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return nil, errNoNotifier
+	}
+	return NotifySend{}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package daemon
+
+import "os/exec"
+
+// NewNotifier returns notify-send notifier if it is installed
+func NewNotifier() (Notifier, error) {
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return nil, errNoNotifier
+	}
+	return NotifySend{}, nil
+}
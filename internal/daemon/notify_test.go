@@ -0,0 +1,174 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage/storagetest"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// fakeNotifier records notifications instead of showing them
+type fakeNotifier struct {
+	notifications []Notification
+}
+
+func (n *fakeNotifier) Notify(notification Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func (n *fakeNotifier) titles() []string {
+	var titles []string
+	for _, notification := range n.notifications {
+		titles = append(titles, notification.Title)
+	}
+	return titles
+}
+
+func TestNotificationHandlerTriggers(t *testing.T) {
+	handler := &NotificationHandler{}
+
+	tests := []struct {
+		name     string
+		config   config.Notifications
+		expected []Trigger
+	}{
+		{name: "Disabled", config: config.Notifications{Disabled: true}},
+		{
+			name:   "Lead times",
+			config: config.Notifications{Prayers: []string{"fajr", "isha"}, Before: []config.Duration{config.Duration(10 * time.Minute)}},
+			expected: []Trigger{
+				{Prayer: "Fajr"},
+				{Prayer: "Fajr", Offset: -10 * time.Minute},
+				{Prayer: "Isha"},
+				{Prayer: "Isha", Offset: -10 * time.Minute},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := handler.Triggers(config.Config{Notifications: tt.config})
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNotificationHandlerHandle(t *testing.T) {
+	schedule, _ := testRepo{}.GetDailyPrayerSchedule(time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+	schedule.Event = domain.Event{En: "Eid al-Fitr"}
+	event := func(offset time.Duration, missed bool) Event {
+		return Event{
+			Trigger:    Trigger{Prayer: "Asr", Offset: offset},
+			At:         schedule.Prayers[2].Time.Add(offset),
+			PrayerTime: schedule.Prayers[2].Time,
+			Schedule:   schedule,
+			Missed:     missed,
+		}
+	}
+
+	notifier := &fakeNotifier{}
+	handler := &NotificationHandler{Notifier: notifier, Log: log.New(io.Discard, "", 0)}
+	handler.Handle(config.Config{}, event(-15*time.Minute, false))
+	handler.Handle(config.Config{}, event(0, true))
+	handler.Handle(config.Config{}, event(0, false))
+
+	expected := []Notification{
+		{Title: "Asr in 15m", Body: "Asr at 3:00 pm\n1 Shawwal 1447 AH\nEid al-Fitr"},
+		{Title: "Asr time", Body: "Asr at 3:00 pm\n1 Shawwal 1447 AH\nEid al-Fitr"},
+	}
+	if !reflect.DeepEqual(notifier.notifications, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, notifier.notifications)
+	}
+}
+
+func TestNotificationHandlerEscalation(t *testing.T) {
+	cfg := config.Config{Notifications: config.Notifications{Reminders: []config.Reminder{
+		{Prayer: "asr", Before: config.Duration(15 * time.Minute)},
+		{Prayer: "sunrise", Before: config.Duration(30 * time.Minute), Escalate: &config.Escalation{After: config.Duration(20 * time.Minute), Persistent: true}},
+	}}}
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		logged   map[string][]string
+		expected []Notification
+	}{
+		{
+			name: "Fajr not logged",
+			expected: []Notification{
+				{Title: "Sunrise in 30m", Body: "Fajr time ends at 6:00 am\n2 Shawwal 1447 AH"},
+				{Title: "Fajr not prayed yet", Body: "Fajr time ends at 6:00 am\n2 Shawwal 1447 AH", Urgency: config.UrgencyCritical, Persistent: true},
+				{Title: "Asr in 15m", Body: "Asr at 3:00 pm\n2 Shawwal 1447 AH"},
+			},
+		},
+		{
+			name:   "Fajr logged",
+			logged: map[string][]string{"2026-03-21": {"Fajr"}},
+			expected: []Notification{
+				{Title: "Sunrise in 30m", Body: "Fajr time ends at 6:00 am\n2 Shawwal 1447 AH"},
+				{Title: "Asr in 15m", Body: "Asr at 3:00 pm\n2 Shawwal 1447 AH"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &fakeNotifier{}
+			prayerLog := &storagetest.MemoryPrayerLogStorage{Log: models.PrayerLogDto{Days: tt.logged}}
+			clock := &fakeClock{now: at(21, 0, 0), end: at(21, 16, 0)}
+			runTestScheduler(&Scheduler{
+				Load: func() (domain.PrayerTimesRepo, config.Config, error) {
+					return testRepo{}, cfg, nil
+				},
+				Handlers: []Handler{&NotificationHandler{Notifier: notifier, PrayerLog: prayerLog, Log: log.New(io.Discard, "", 0)}},
+			}, clock)
+
+			if !reflect.DeepEqual(notifier.notifications, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, notifier.notifications)
+			}
+		})
+	}
+}
+
+func TestNotificationHandlerEscalationNotBeforePrayer(t *testing.T) {
+	cfg := config.Config{Notifications: config.Notifications{Reminders: []config.Reminder{
+		{Prayer: "asr", Before: config.Duration(15 * time.Minute), Escalate: &config.Escalation{After: config.Duration(10 * time.Minute)}},
+		{Prayer: "maghrib", Before: config.Duration(15 * time.Minute), Escalate: &config.Escalation{After: config.Duration(30 * time.Minute)}},
+		{Prayer: "sunrise", Before: config.Duration(30 * time.Minute), Escalate: &config.Escalation{After: config.Duration(20 * time.Minute)}},
+	}}}
+
+	handler := &NotificationHandler{PrayerLog: &storagetest.MemoryPrayerLogStorage{}}
+	expected := []Trigger{
+		{Prayer: "Asr", Offset: -15 * time.Minute},
+		{Prayer: "Asr", Name: "escalate 0"},
+		{Prayer: "Maghrib", Offset: -15 * time.Minute},
+		{Prayer: "Maghrib", Offset: 15 * time.Minute, Name: "escalate 0"},
+		{Prayer: "Sunrise", Offset: -30 * time.Minute},
+		{Prayer: "Sunrise", Offset: -10 * time.Minute, Name: "escalate 0"},
+	}
+	if triggers := handler.Triggers(cfg); !reflect.DeepEqual(triggers, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, triggers)
+	}
+}
+
+func TestNotificationHandlerEscalationWithoutLog(t *testing.T) {
+	cfg := config.Config{Notifications: config.Notifications{Reminders: []config.Reminder{
+		{Prayer: "asr", Escalate: &config.Escalation{After: config.Duration(time.Hour)}},
+	}}}
+
+	triggers := (&NotificationHandler{}).Triggers(cfg)
+	if expected := []Trigger{{Prayer: "Asr"}}; !reflect.DeepEqual(triggers, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, triggers)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build linux
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processStartTime returns start time of process @pid, in clock ticks since
+// boot, as kernel reports it in /proc
+func processStartTime(pid int) (string, error) {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return "", err
+	}
+	// command name in parentheses may have spaces, fields after it start
+	// from state, third field of stat, and start time is 22nd
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	if len(fields) < 20 {
+		return "", fmt.Errorf("invalid stat of process %v", pid)
+	}
+	return fields[19], nil
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// processStartTime returns start time of process @pid as ps prints it
+func processStartTime(pid int) (string, error) {
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", err
+	}
+	start := strings.TrimSpace(string(out))
+	if start == "" {
+		return "", errors.New("no such process")
+	}
+	return start, nil
+}
@@ -0,0 +1,200 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// Handler acts on prayer events, e.g. by sending notifications
+type Handler interface {
+	// Triggers returns moments handler acts at, given @cfg
+	Triggers(cfg config.Config) []Trigger
+
+	// Handle is called when event of one of its triggers comes. It runs on
+	// scheduler loop, so anything slow must run in its own goroutine
+	Handle(cfg config.Config, event Event)
+}
+
+// Scheduler calls handlers at their triggers of each day. It wakes at least
+// every @MaxSleep, comparing wall clock to last wake, so events are not lost
+// or repeated when machine suspends, clock jumps or day rolls over
+type Scheduler struct {
+	// Load reads config and creates repo. It is called on start, and again
+	// whenever one of @Watch files changes
+	Load func() (domain.PrayerTimesRepo, config.Config, error)
+
+	// Watch returns files to reload on change at @now, e.g. config and year files
+	Watch func(now time.Time) []string
+
+	Handlers []Handler
+
+	Now   func() time.Time
+	After func(d time.Duration) <-chan time.Time
+
+	// MaxSleep caps time between wakes. Timers do not count time spent
+	// suspended, waking often is how resume is noticed
+	MaxSleep time.Duration
+
+	// Grace is how late an event can fire before it is marked missed
+	Grace time.Duration
+
+	Log *log.Logger
+}
+
+// maxCatchUp is how far back events are looked for after a long suspend
+const maxCatchUp = 24 * time.Hour
+
+// schedulerState is what scheduler keeps between wakes
+type schedulerState struct {
+	repo    domain.PrayerTimesRepo
+	config  config.Config
+	loaded  bool
+	modTime map[string]time.Time
+
+	// events cover days from planFrom to planTo
+	events   []plannedEvent
+	planFrom time.Time
+	planTo   time.Time
+	planned  bool
+
+	// fired keeps events already handled, so clock moving back or a new plan
+	// does not repeat them
+	fired map[firedEvent]bool
+
+	lastErr string
+}
+
+type firedEvent struct {
+	handler int
+	trigger Trigger
+	// at in unix seconds, times of same instant may differ as map keys
+	at int64
+}
+
+// Run calls handlers until @ctx is done
+func (s *Scheduler) Run(ctx context.Context) error {
+	state := &schedulerState{fired: map[firedEvent]bool{}}
+	last := s.Now()
+
+	for {
+		now := s.Now()
+		if now.Before(last) {
+			s.Log.Printf("clock moved back from %v to %v", last.Format(time.DateTime), now.Format(time.DateTime))
+			last = now
+		}
+		if now.Sub(last) > maxCatchUp {
+			last = now.Add(-maxCatchUp)
+		}
+
+		wait := s.MaxSleep
+		if s.wake(state, last, now) {
+			for _, event := range state.events {
+				if !event.At.After(last) {
+					continue
+				}
+				if event.At.After(now) {
+					wait = min(wait, event.At.Sub(now))
+					break
+				}
+				key := firedEvent{handler: event.handler, trigger: event.Trigger, at: event.At.Unix()}
+				if state.fired[key] {
+					continue
+				}
+				state.fired[key] = true
+				event.Missed = now.Sub(event.At) > s.Grace
+				s.Handlers[event.handler].Handle(state.config, event.Event)
+			}
+		}
+		last = now
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.After(wait):
+		}
+	}
+}
+
+// wake reloads if watched files changed and plans events from day of @last
+// to day after @now. It returns false if there is nothing to run yet
+func (s *Scheduler) wake(state *schedulerState, last time.Time, now time.Time) bool {
+	changed := s.watchedFilesChanged(state, now)
+	if !state.loaded || changed {
+		repo, cfg, err := s.Load()
+		if err != nil {
+			s.logError(state, err)
+			return state.loaded
+		}
+		state.repo, state.config, state.loaded = repo, cfg, true
+		state.planned = false
+		s.Log.Printf("loaded config")
+	}
+
+	from := startOfDay(last)
+	to := startOfDay(now).AddDate(0, 0, 1)
+	if state.planned && state.planFrom.Equal(from) && state.planTo.Equal(to) {
+		return true
+	}
+
+	var schedules []domain.DailyPrayerSchedule
+	// yesterday too, so triggers after isha running past midnight are not
+	// lost. A missing day, e.g. next year not published yet, is skipped
+	for day := from.AddDate(0, 0, -1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		schedule, err := state.repo.GetDailyPrayerSchedule(day)
+		if err != nil {
+			s.logError(state, err)
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+	if len(schedules) == 0 {
+		return state.planned
+	}
+
+	state.events = planEvents(schedules, handlerTriggers(s.Handlers, state.config))
+	for key := range state.fired {
+		if key.at < from.AddDate(0, 0, -1).Unix() {
+			delete(state.fired, key)
+		}
+	}
+	state.planFrom, state.planTo, state.planned = from, to, true
+	return true
+}
+
+// watchedFilesChanged compares modification times of watched files to
+// previous wake. First call only records them
+func (s *Scheduler) watchedFilesChanged(state *schedulerState, now time.Time) bool {
+	if s.Watch == nil {
+		return false
+	}
+
+	modTime := map[string]time.Time{}
+	changed := false
+	for _, path := range s.Watch(now) {
+		if info, err := os.Stat(path); err == nil {
+			modTime[path] = info.ModTime()
+		}
+		if state.modTime != nil && !state.modTime[path].Equal(modTime[path]) {
+			changed = true
+		}
+	}
+	state.modTime = modTime
+	return changed
+}
+
+// logError logs @err unless it is the same as previous error
+func (s *Scheduler) logError(state *schedulerState, err error) {
+	if err.Error() != state.lastErr {
+		s.Log.Printf("error: %v", err)
+		state.lastErr = err.Error()
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,258 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// testRepo has same prayer times every day
+type testRepo struct {
+	domain.PrayerTimesRepo
+}
+
+func (testRepo) GetDailyPrayerSchedule(date time.Time) (domain.DailyPrayerSchedule, error) {
+	at := func(hour int) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, time.UTC)
+	}
+	return domain.DailyPrayerSchedule{
+		Date:    at(0),
+		Hijri:   domain.HijriDate{Year: 1447, Month: 10, Day: date.Day() - 19},
+		Sunrise: at(6),
+		Prayers: []domain.Prayer{
+			{Name: "Fajr", Time: at(5)},
+			{Name: "Dhuhr", Time: at(12)},
+			{Name: "Asr", Time: at(15)},
+			{Name: "Maghrib", Time: at(18)},
+			{Name: "Isha", Time: at(19)},
+		},
+	}, nil
+}
+
+// fakeClock moves forward by each requested sleep at once, running steps
+// it passes on the way. It cancels run when it reaches end
+type fakeClock struct {
+	now    time.Time
+	end    time.Time
+	steps  []clockStep
+	cancel context.CancelFunc
+}
+
+type clockStep struct {
+	at time.Time
+	do func(c *fakeClock)
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	next := c.now.Add(d)
+	if len(c.steps) > 0 && !next.Before(c.steps[0].at) {
+		step := c.steps[0]
+		c.steps = c.steps[1:]
+		c.now = step.at
+		step.do(c)
+	} else {
+		c.now = next
+	}
+
+	if c.now.After(c.end) {
+		c.cancel()
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// recordingHandler wants @triggers and records events it gets as "Prayer offset at"
+type recordingHandler struct {
+	triggers []Trigger
+	events   []string
+	onHandle func()
+}
+
+func (h *recordingHandler) Triggers(cfg config.Config) []Trigger {
+	return h.triggers
+}
+
+func (h *recordingHandler) Handle(cfg config.Config, event Event) {
+	record := fmt.Sprintf("%v %v %v", event.Prayer, event.Offset, event.At.Format("02 15:04"))
+	if event.Missed {
+		record += " missed"
+	}
+	h.events = append(h.events, record)
+	if h.onHandle != nil {
+		h.onHandle()
+	}
+}
+
+func runTestScheduler(scheduler *Scheduler, clock *fakeClock) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clock.cancel = cancel
+	scheduler.Now = clock.Now
+	scheduler.After = clock.After
+	scheduler.MaxSleep = time.Minute
+	scheduler.Grace = 2 * time.Minute
+	scheduler.Log = log.New(io.Discard, "", 0)
+	if scheduler.Load == nil {
+		scheduler.Load = func() (domain.PrayerTimesRepo, config.Config, error) {
+			return testRepo{}, config.Config{}, nil
+		}
+	}
+	scheduler.Run(ctx)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	triggers := []Trigger{
+		{Prayer: "Fajr", Offset: -15 * time.Minute},
+		{Prayer: "Asr"},
+		{Prayer: "Isha", Offset: 5 * time.Hour},
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		steps    []clockStep
+		expected []string
+	}{
+		{
+			name:     "Events of the day",
+			start:    at(20, 10, 0),
+			end:      at(20, 23, 0),
+			expected: []string{"Asr 0s 20 15:00"},
+		},
+		{
+			name:     "Day rollover",
+			start:    at(20, 16, 0),
+			end:      at(21, 16, 0),
+			expected: []string{"Isha 5h0m0s 21 00:00", "Fajr -15m0s 21 04:45", "Asr 0s 21 15:00"},
+		},
+		{
+			name:  "Suspend past events",
+			start: at(20, 10, 0),
+			end:   at(21, 6, 0),
+			steps: []clockStep{
+				{at: at(20, 14, 30), do: func(c *fakeClock) { c.now = at(21, 5, 0) }},
+			},
+			expected: []string{"Asr 0s 20 15:00 missed", "Isha 5h0m0s 21 00:00 missed", "Fajr -15m0s 21 04:45 missed"},
+		},
+		{
+			name:  "Resume within grace",
+			start: at(20, 14, 0),
+			end:   at(20, 16, 0),
+			steps: []clockStep{
+				{at: at(20, 14, 59), do: func(c *fakeClock) { c.now = at(20, 15, 1) }},
+			},
+			expected: []string{"Asr 0s 20 15:00"},
+		},
+		{
+			name:  "Clock moved back",
+			start: at(20, 14, 0),
+			end:   at(20, 17, 0),
+			steps: []clockStep{
+				{at: at(20, 15, 30), do: func(c *fakeClock) { c.now = at(20, 14, 30) }},
+			},
+			expected: []string{"Asr 0s 20 15:00"},
+		},
+		{
+			name:  "Clock moved forward",
+			start: at(20, 23, 0),
+			end:   at(21, 1, 0),
+			steps: []clockStep{
+				{at: at(20, 23, 30), do: func(c *fakeClock) { c.now = at(21, 0, 30) }},
+			},
+			expected: []string{"Isha 5h0m0s 21 00:00 missed"},
+		},
+		{
+			name:     "Events before start are not fired",
+			start:    at(20, 15, 0).Add(time.Second),
+			end:      at(20, 16, 0),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &recordingHandler{triggers: triggers}
+			clock := &fakeClock{now: tt.start, end: tt.end, steps: tt.steps}
+			runTestScheduler(&Scheduler{Handlers: []Handler{handler}}, clock)
+
+			if !reflect.DeepEqual(handler.events, tt.expected) {
+				t.Errorf("Expected %q but got %q", tt.expected, handler.events)
+			}
+		})
+	}
+}
+
+func TestSchedulerWakesAtEvent(t *testing.T) {
+	start := time.Date(2026, 3, 20, 14, 59, 30, 0, time.UTC)
+	clock := &fakeClock{now: start, end: start.Add(time.Hour)}
+
+	var firedAt []time.Time
+	handler := &recordingHandler{
+		triggers: []Trigger{{Prayer: "Asr"}},
+		onHandle: func() { firedAt = append(firedAt, clock.now) },
+	}
+	runTestScheduler(&Scheduler{Handlers: []Handler{handler}}, clock)
+
+	expected := []time.Time{time.Date(2026, 3, 20, 15, 0, 0, 0, time.UTC)}
+	if !reflect.DeepEqual(firedAt, expected) {
+		t.Errorf("Expected to fire at %v but got %v", expected, firedAt)
+	}
+}
+
+func TestSchedulerReloadsOnChange(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), config.FileName)
+	if err := os.WriteFile(configPath, []byte(`{"notifications": {"prayers": ["asr"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(hour int) time.Time {
+		return time.Date(2026, 3, 20, hour, 0, 0, 0, time.UTC)
+	}
+	clock := &fakeClock{now: at(10), end: at(20), steps: []clockStep{
+		{at: at(16), do: func(c *fakeClock) {
+			os.WriteFile(configPath, []byte(`{"notifications": {"prayers": ["asr", "maghrib"], "before": ["10m"]}}`), 0644)
+			os.Chtimes(configPath, time.Now(), time.Now().Add(time.Hour))
+		}},
+	}}
+
+	notifier := &fakeNotifier{}
+	loads := 0
+	scheduler := &Scheduler{
+		Load: func() (domain.PrayerTimesRepo, config.Config, error) {
+			loads++
+			cfg, err := config.Load(configPath)
+			return testRepo{}, cfg, err
+		},
+		Watch: func(now time.Time) []string {
+			return []string{configPath}
+		},
+		Handlers: []Handler{&NotificationHandler{Notifier: notifier, Log: log.New(io.Discard, "", 0)}},
+	}
+	runTestScheduler(scheduler, clock)
+
+	if loads != 2 {
+		t.Errorf("Expected config to be loaded on start and on change but got %v loads", loads)
+	}
+	expected := []string{"Asr time", "Maghrib in 10m", "Maghrib time"}
+	if titles := notifier.titles(); !reflect.DeepEqual(titles, expected) {
+		t.Errorf("Expected %q but got %q", expected, titles)
+	}
+}
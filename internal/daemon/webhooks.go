@@ -0,0 +1,448 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+)
+
+// webhookTrigger names triggers of webhooks, followed by index of endpoint
+// and of its event in config
+const webhookTrigger = "webhook"
+
+const (
+	defaultRetryDelay    = 30 * time.Second
+	defaultMaxRetryDelay = time.Hour
+	webhookTimeout       = 10 * time.Second
+
+	// maxWebhookSleep caps sleep of delivery loop, timers do not count time
+	// spent suspended
+	maxWebhookSleep = time.Minute
+
+	// deliveryLease is how long a delivery being posted is not due for other
+	// processes sharing outbox
+	deliveryLease = time.Minute
+)
+
+// Headers of webhook posts. Signature is hex HMAC-SHA256 of timestamp, a dot
+// and body, keyed by endpoint secret
+const (
+	WebhookDeliveryHeader  = "X-Prayers-Delivery"
+	WebhookTimestampHeader = "X-Prayers-Timestamp"
+	WebhookSignatureHeader = "X-Prayers-Signature"
+)
+
+// WebhookPayload is JSON body posted to webhooks
+type WebhookPayload struct {
+	// Event as in config, e.g. before:asr:10m
+	Event  string `json:"event"`
+	Prayer string `json:"prayer"`
+
+	// Time of prayer, and At time of event
+	Time time.Time `json:"time"`
+	At   time.Time `json:"at"`
+
+	Location string `json:"location,omitempty"`
+
+	// Hijri date of prayer day, e.g. 1447-09-27
+	Hijri string `json:"hijri,omitempty"`
+
+	// DayEvent of prayer day, e.g. Eid al-Fitr
+	DayEvent *WebhookDayEvent `json:"dayEvent,omitempty"`
+
+	// Missed is set on events delivered late after machine was suspended
+	Missed bool `json:"missed"`
+}
+
+// WebhookDayEvent is event name of prayer day in english and arabic
+type WebhookDayEvent struct {
+	En string `json:"en"`
+	Ar string `json:"ar"`
+}
+
+// delivery is a payload waiting in outbox to be posted
+type delivery struct {
+	// ID is same for each attempt, so receivers can ignore repeated posts
+	ID      string          `json:"id"`
+	URL     string          `json:"url"`
+	Secret  string          `json:"secret,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+
+	// Expires is when delivery is dropped if not done
+	Expires     time.Time `json:"expires"`
+	MaxAttempts int       `json:"maxAttempts"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+// WebhookHandler posts events of config webhooks section. Handle queues
+// payloads in an outbox file, and @Run posts them, retrying failed posts
+// with exponential backoff until they expire or run out of attempts. Outbox
+// survives restarts, so nothing queued is lost when daemon stops, and is
+// shared by processes using same file, e.g. daemon and prayers fire
+type WebhookHandler struct {
+	// Outbox is file pending deliveries are kept in. It is read again under
+	// a lock before each change
+	Outbox string
+
+	// Client posts payloads, one with a 10s timeout if nil
+	Client *http.Client
+
+	// RetryDelay is delay before first retry, doubling after each failed
+	// attempt up to MaxRetryDelay. 30s and 1h if zero
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	Log *log.Logger
+
+	mu         sync.Mutex
+	deliveries []delivery
+	wake       chan struct{}
+}
+
+func (h *WebhookHandler) Triggers(cfg config.Config) []Trigger {
+	var triggers []Trigger
+	for i, webhook := range cfg.Webhooks.Endpoints {
+		for j, on := range webhook.On {
+			prayer, offset, err := on.Parse()
+			if err != nil {
+				continue
+			}
+			triggers = append(triggers, Trigger{Prayer: prayer, Offset: offset, Name: fmt.Sprintf("%v %v %v", webhookTrigger, i, j)})
+		}
+	}
+	return triggers
+}
+
+// Handle queues payload of @event, skipping missed events unless config
+// asks to deliver them late
+func (h *WebhookHandler) Handle(cfg config.Config, event Event) {
+	var i, j int
+	if _, err := fmt.Sscanf(event.Name, webhookTrigger+" %d %d", &i, &j); err != nil || i >= len(cfg.Webhooks.Endpoints) || j >= len(cfg.Webhooks.Endpoints[i].On) {
+		return
+	}
+	webhook := cfg.Webhooks.Endpoints[i]
+	on := strings.ToLower(strings.TrimSpace(string(webhook.On[j])))
+	if event.Missed && cfg.Webhooks.Missed != config.MissedDeliver {
+		h.Log.Printf("skipped missed webhook %v: %v", on, webhook.URL)
+		return
+	}
+
+	payload := WebhookPayload{
+		Event:    on,
+		Prayer:   event.Prayer,
+		Time:     event.PrayerTime,
+		At:       event.At,
+		Location: cfg.Webhooks.Location,
+		Missed:   event.Missed,
+	}
+	if !event.Schedule.Hijri.IsZero() {
+		payload.Hijri = event.Schedule.Hijri.String()
+	}
+	if !event.Schedule.Event.IsZero() {
+		payload.DayEvent = &WebhookDayEvent{En: event.Schedule.Event.En, Ar: event.Schedule.Event.Ar}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		h.Log.Printf("error: webhook %v: %v", on, err)
+		return
+	}
+
+	h.enqueue(delivery{
+		ID:          deliveryID(webhook.URL, on, event.At),
+		URL:         webhook.URL,
+		Secret:      webhook.Secret,
+		Payload:     body,
+		Expires:     event.At.Add(cfg.Webhooks.DeliveryMaxAge()),
+		MaxAttempts: cfg.Webhooks.MaxAttempts(),
+		NextAttempt: time.Now(),
+	})
+}
+
+// Run posts queued payloads until @ctx is done
+func (h *WebhookHandler) Run(ctx context.Context) {
+	wake := h.wakeChan()
+	for {
+		sleep := maxWebhookSleep
+		if next := h.Deliver(ctx); !next.IsZero() {
+			sleep = min(max(time.Until(next), 0), maxWebhookSleep)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-time.After(sleep):
+		}
+	}
+}
+
+// Deliver posts queued payloads that are due once, and returns when next
+// one is due, or zero time if outbox is empty
+func (h *WebhookHandler) Deliver(ctx context.Context) time.Time {
+	for _, d := range h.due(time.Now()) {
+		if ctx.Err() != nil {
+			break
+		}
+		h.finish(d, h.post(ctx, d))
+	}
+
+	var next time.Time
+	h.update(func() bool {
+		for _, d := range h.deliveries {
+			if next.IsZero() || d.NextAttempt.Before(next) {
+				next = d.NextAttempt
+			}
+		}
+		return false
+	})
+	return next
+}
+
+// Pending returns number of payloads waiting in outbox
+func (h *WebhookHandler) Pending() int {
+	var pending int
+	h.update(func() bool {
+		pending = len(h.deliveries)
+		return false
+	})
+	return pending
+}
+
+func (h *WebhookHandler) enqueue(d delivery) {
+	queued := h.update(func() bool {
+		if slices.ContainsFunc(h.deliveries, func(queued delivery) bool { return queued.ID == d.ID }) {
+			return false
+		}
+		h.deliveries = append(h.deliveries, d)
+		return true
+	})
+	if !queued {
+		return
+	}
+
+	select {
+	case h.wakeChan() <- struct{}{}:
+	default:
+	}
+}
+
+// due drops expired deliveries and returns ones due at @now, leasing them
+// so other processes do not post them at same time
+func (h *WebhookHandler) due(now time.Time) []delivery {
+	var due []delivery
+	h.update(func() bool {
+		changed := false
+		h.deliveries = slices.DeleteFunc(h.deliveries, func(d delivery) bool {
+			if now.After(d.Expires) {
+				h.Log.Printf("dropped webhook %v to %v, expired after %v attempts, last error: %v", d.ID, d.URL, d.Attempts, d.LastError)
+				changed = true
+				return true
+			}
+			return false
+		})
+		for i, d := range h.deliveries {
+			if !d.NextAttempt.After(now) {
+				due = append(due, d)
+				h.deliveries[i].NextAttempt = now.Add(deliveryLease)
+				changed = true
+			}
+		}
+		return changed
+	})
+	return due
+}
+
+// finish removes @d from outbox if it is delivered, or can not be, and
+// schedules its next attempt otherwise
+func (h *WebhookHandler) finish(d delivery, err error) {
+	h.update(func() bool {
+		i := slices.IndexFunc(h.deliveries, func(queued delivery) bool { return queued.ID == d.ID })
+		if i < 0 {
+			return false
+		}
+		d = h.deliveries[i]
+		d.Attempts++
+
+		var permanent *permanentError
+		switch {
+		case err == nil:
+			h.Log.Printf("delivered webhook %v to %v", d.ID, d.URL)
+		case errors.As(err, &permanent):
+			h.Log.Printf("dropped webhook %v to %v: %v", d.ID, d.URL, err)
+		case d.Attempts >= d.MaxAttempts:
+			h.Log.Printf("dropped webhook %v to %v after %v attempts: %v", d.ID, d.URL, d.Attempts, err)
+		default:
+			delay := h.retryDelay(d.Attempts)
+			d.NextAttempt = time.Now().Add(delay)
+			d.LastError = err.Error()
+			h.deliveries[i] = d
+			h.Log.Printf("error: webhook %v to %v, attempt %v: %v, retrying in %v", d.ID, d.URL, d.Attempts, err, delay)
+			return true
+		}
+		h.deliveries = slices.Delete(h.deliveries, i, i+1)
+		return true
+	})
+}
+
+// retryDelay returns delay after @attempts failed attempts
+func (h *WebhookHandler) retryDelay(attempts int) time.Duration {
+	delay, maxDelay := h.RetryDelay, h.MaxRetryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// permanentError is a failed post that is not retried, e.g. 404
+type permanentError struct {
+	status string
+}
+
+func (e *permanentError) Error() string {
+	return e.status
+}
+
+func (h *WebhookHandler) post(ctx context.Context, d delivery) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return &permanentError{err.Error()}
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "prayer-times-cli")
+	request.Header.Set(WebhookDeliveryHeader, d.ID)
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	if d.Secret != "" {
+		request.Header.Set(WebhookSignatureHeader, "sha256="+webhookSignature(d.Secret, timestamp, d.Payload))
+	}
+
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	switch code := response.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+	case code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests:
+		return &permanentError{response.Status}
+	}
+	return errors.New(response.Status)
+}
+
+// webhookSignature is hex HMAC-SHA256 of @timestamp and @body keyed by @secret
+func webhookSignature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliveryID is same for an event of an endpoint, so an event fired twice,
+// e.g. by daemon and schedule sharing outbox, is posted once
+func deliveryID(url string, event string, at time.Time) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%v\n%v\n%v", url, event, at.Unix())))
+	return hex.EncodeToString(hash[:8])
+}
+
+// update reads outbox, calls @change and saves outbox if it returns true,
+// holding a lock on outbox so other processes do not change it meanwhile.
+// It returns what @change returns
+func (h *WebhookHandler) update(change func() bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lock, err := os.OpenFile(filepath.Join(filepath.Dir(h.Outbox), "."+filepath.Base(h.Outbox)+".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err == nil {
+		defer lock.Close()
+		err = lockFile(lock)
+	}
+	if err != nil {
+		h.Log.Printf("error: webhook outbox lock: %v", err)
+	}
+
+	h.load()
+	changed := change()
+	if changed {
+		h.save()
+	}
+	return changed
+}
+
+// load reads outbox, keeping deliveries read last if it can not be read
+func (h *WebhookHandler) load() {
+	data, err := os.ReadFile(h.Outbox)
+	if errors.Is(err, os.ErrNotExist) {
+		h.deliveries = nil
+		return
+	}
+	if err == nil {
+		var deliveries []delivery
+		if err = json.Unmarshal(data, &deliveries); err == nil {
+			h.deliveries = deliveries
+			return
+		}
+	}
+	h.Log.Printf("error: webhook outbox %v: %v", h.Outbox, err)
+}
+
+// save writes outbox, through a temporary file so a crash does not leave it
+// half written, or removes it when empty. It is private to user as it holds
+// secrets
+func (h *WebhookHandler) save() {
+	if len(h.deliveries) == 0 {
+		if err := os.Remove(h.Outbox); err != nil && !errors.Is(err, os.ErrNotExist) {
+			h.Log.Printf("error: webhook outbox %v: %v", h.Outbox, err)
+		}
+		return
+	}
+	data, err := json.MarshalIndent(h.deliveries, "", "  ")
+	if err == nil {
+		tmp := filepath.Join(filepath.Dir(h.Outbox), "."+filepath.Base(h.Outbox)+".tmp")
+		if err = os.WriteFile(tmp, data, 0600); err == nil {
+			err = os.Rename(tmp, h.Outbox)
+		}
+	}
+	if err != nil {
+		h.Log.Printf("error: webhook outbox %v: %v", h.Outbox, err)
+	}
+}
+
+func (h *WebhookHandler) wakeChan() chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.wake == nil {
+		h.wake = make(chan struct{}, 1)
+	}
+	return h.wake
+}
@@ -0,0 +1,332 @@
+package daemon
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/config"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// webhookReceiver records posts it gets, answering with @statuses in order
+// and 200 once they run out
+type webhookReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedWebhook
+	received chan struct{}
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	t.Helper()
+	r := &webhookReceiver{statuses: statuses, received: make(chan struct{}, 100)}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedWebhook{header: req.Header, body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		r.received <- struct{}{}
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) posts() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.requests...)
+}
+
+// waitPosts waits for @n more posts
+func (r *webhookReceiver) waitPosts(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-r.received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for webhook posts, got %v", len(r.posts()))
+		}
+	}
+}
+
+func newWebhookHandler(t *testing.T) *WebhookHandler {
+	return &WebhookHandler{
+		Outbox:        filepath.Join(t.TempDir(), "webhooks-outbox.json"),
+		RetryDelay:    10 * time.Millisecond,
+		MaxRetryDelay: 40 * time.Millisecond,
+		Log:           log.New(io.Discard, "", 0),
+	}
+}
+
+// webhookEvent is event of @trigger of asr at @at
+func webhookEvent(trigger string, at time.Time, missed bool) Event {
+	return Event{
+		Trigger:    Trigger{Prayer: "Asr", Name: trigger},
+		At:         at,
+		PrayerTime: at,
+		Schedule: domain.DailyPrayerSchedule{
+			Hijri: domain.HijriDate{Year: 1447, Month: 9, Day: 27},
+			Event: domain.Event{En: "Laylat al-Qadr", Ar: "ليلة القدر"},
+		},
+		Missed: missed,
+	}
+}
+
+func TestWebhookHandlerTriggers(t *testing.T) {
+	cfg := config.Config{Webhooks: config.Webhooks{Endpoints: []config.Webhook{
+		{URL: "http://a", On: []config.HookEvent{"at:maghrib", "before:asr:10m"}},
+		{URL: "http://b", On: []config.HookEvent{"after:isha:5m"}},
+	}}}
+
+	expected := []Trigger{
+		{Prayer: "Maghrib", Name: "webhook 0 0"},
+		{Prayer: "Asr", Offset: -10 * time.Minute, Name: "webhook 0 1"},
+		{Prayer: "Isha", Offset: 5 * time.Minute, Name: "webhook 1 0"},
+	}
+	if triggers := (&WebhookHandler{}).Triggers(cfg); !reflect.DeepEqual(triggers, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, triggers)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	receiver := newWebhookReceiver(t)
+	cfg := config.Config{Webhooks: config.Webhooks{
+		Location:  "Beirut",
+		Endpoints: []config.Webhook{{URL: receiver.URL, Secret: "s3cret", On: []config.HookEvent{" At:Asr "}}},
+	}}
+	handler := newWebhookHandler(t)
+	at := time.Now().Truncate(time.Second)
+
+	handler.Handle(cfg, webhookEvent("webhook 0 0", at, false))
+	handler.Deliver(context.Background())
+
+	posts := receiver.posts()
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 post but got %v", len(posts))
+	}
+	post := posts[0]
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(post.header.Get("X-Prayers-Timestamp") + "." + string(post.body)))
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); post.header.Get("X-Prayers-Signature") != expected {
+		t.Errorf("Expected signature %v but got %v", expected, post.header.Get("X-Prayers-Signature"))
+	}
+	if post.header.Get("Content-Type") != "application/json" || post.header.Get("X-Prayers-Delivery") == "" {
+		t.Errorf("Expected json content type and delivery id but got %v", post.header)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(post.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	expected := WebhookPayload{
+		Event:    "at:asr",
+		Prayer:   "Asr",
+		Time:     at,
+		At:       at,
+		Location: "Beirut",
+		Hijri:    "1447-09-27",
+		DayEvent: &WebhookDayEvent{En: "Laylat al-Qadr", Ar: "ليلة القدر"},
+	}
+	if !payload.Time.Equal(at) || !payload.At.Equal(at) {
+		t.Errorf("Expected times %v but got %v %v", at, payload.Time, payload.At)
+	}
+	payload.Time, payload.At = at, at
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, payload)
+	}
+
+	if handler.Pending() != 0 {
+		t.Errorf("Expected empty outbox but got %v pending", handler.Pending())
+	}
+	if _, err := os.Stat(handler.Outbox); !os.IsNotExist(err) {
+		t.Errorf("Expected outbox file to be removed when empty, got %v", err)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      []int
+		attempts      int
+		expectedPosts int
+	}{
+		{name: "Delivered after failures", statuses: []int{503, 500, 429}, expectedPosts: 4},
+		{name: "Dropped after max attempts", statuses: []int{500, 500, 500, 500}, attempts: 3, expectedPosts: 3},
+		{name: "Client error is not retried", statuses: []int{404}, expectedPosts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := newWebhookReceiver(t, tt.statuses...)
+			cfg := config.Config{Webhooks: config.Webhooks{
+				Attempts:  tt.attempts,
+				Endpoints: []config.Webhook{{URL: receiver.URL, On: []config.HookEvent{"at:asr"}}},
+			}}
+			handler := newWebhookHandler(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				handler.Run(ctx)
+				close(done)
+			}()
+			handler.Handle(cfg, webhookEvent("webhook 0 0", time.Now(), false))
+			receiver.waitPosts(t, tt.expectedPosts)
+
+			// no more posts come once delivery is done or dropped
+			deadline := time.Now().Add(time.Second)
+			for handler.Pending() > 0 && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+			<-done
+
+			posts := receiver.posts()
+			if len(posts) != tt.expectedPosts {
+				t.Errorf("Expected %v posts but got %v", tt.expectedPosts, len(posts))
+			}
+			for _, post := range posts[1:] {
+				if id := post.header.Get("X-Prayers-Delivery"); id != posts[0].header.Get("X-Prayers-Delivery") {
+					t.Errorf("Expected same delivery id on retries but got %v", id)
+				}
+			}
+			if handler.Pending() != 0 {
+				t.Errorf("Expected empty outbox but got %v pending", handler.Pending())
+			}
+		})
+	}
+}
+
+func TestWebhookOutboxSurvivesRestart(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusServiceUnavailable)
+	cfg := config.Config{Webhooks: config.Webhooks{Endpoints: []config.Webhook{{URL: receiver.URL, On: []config.HookEvent{"at:asr"}}}}}
+
+	first := newWebhookHandler(t)
+	first.Handle(cfg, webhookEvent("webhook 0 0", time.Now(), false))
+	first.Deliver(context.Background())
+	if first.Pending() != 1 {
+		t.Fatalf("Expected failed post to stay in outbox but got %v pending", first.Pending())
+	}
+
+	second := newWebhookHandler(t)
+	second.Outbox = first.Outbox
+	if second.Pending() != 1 {
+		t.Fatalf("Expected outbox to be loaded but got %v pending", second.Pending())
+	}
+	if info, err := os.Stat(second.Outbox); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected outbox private to user, got %v %v", info, err)
+	}
+
+	// next attempt is after retry delay
+	time.Sleep(20 * time.Millisecond)
+	if next := second.Deliver(context.Background()); !next.IsZero() {
+		t.Errorf("Expected nothing left to deliver but next is at %v", next)
+	}
+	posts := receiver.posts()
+	if len(posts) != 2 {
+		t.Fatalf("Expected 2 posts but got %v", len(posts))
+	}
+	if posts[0].header.Get("X-Prayers-Delivery") != posts[1].header.Get("X-Prayers-Delivery") {
+		t.Errorf("Expected same delivery id after restart but got %v", posts[1].header)
+	}
+}
+
+func TestWebhookOutboxShared(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	cfg := config.Config{Webhooks: config.Webhooks{Endpoints: []config.Webhook{{URL: receiver.URL, On: []config.HookEvent{"at:asr", "at:maghrib"}}}}}
+	at := time.Now().Truncate(time.Second)
+
+	// e.g. daemon and prayers fire, both read outbox before either changes it
+	daemon := newWebhookHandler(t)
+	fire := newWebhookHandler(t)
+	fire.Outbox = daemon.Outbox
+	daemon.Pending()
+	fire.Pending()
+
+	daemon.Handle(cfg, webhookEvent("webhook 0 0", at, false))
+	fire.Handle(cfg, webhookEvent("webhook 0 1", at, false))
+	// same event fired by both is queued once
+	fire.Handle(cfg, webhookEvent("webhook 0 0", at, false))
+	if pending := daemon.Pending(); pending != 2 {
+		t.Fatalf("Expected 2 pending but got %v", pending)
+	}
+
+	// posts failed by one are retried by other
+	fire.Deliver(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	daemon.Deliver(context.Background())
+	if pending := fire.Pending(); pending != 0 {
+		t.Errorf("Expected empty outbox but got %v pending", pending)
+	}
+	if posts := receiver.posts(); len(posts) != 4 {
+		t.Errorf("Expected 4 posts but got %v", len(posts))
+	}
+}
+
+func TestWebhookMissedEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        config.MissedPolicy
+		at            time.Time
+		expectedPosts int
+	}{
+		{name: "Dropped by default", at: time.Now().Add(-time.Hour)},
+		{name: "Delivered late", policy: config.MissedDeliver, at: time.Now().Add(-time.Hour), expectedPosts: 1},
+		{name: "Expired", policy: config.MissedDeliver, at: time.Now().Add(-25 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := newWebhookReceiver(t)
+			cfg := config.Config{Webhooks: config.Webhooks{
+				Missed:    tt.policy,
+				Endpoints: []config.Webhook{{URL: receiver.URL, On: []config.HookEvent{"at:asr"}}},
+			}}
+			handler := newWebhookHandler(t)
+
+			handler.Handle(cfg, webhookEvent("webhook 0 0", tt.at, true))
+			handler.Deliver(context.Background())
+
+			posts := receiver.posts()
+			if len(posts) != tt.expectedPosts {
+				t.Fatalf("Expected %v posts but got %v", tt.expectedPosts, len(posts))
+			}
+			if len(posts) > 0 {
+				var payload WebhookPayload
+				if err := json.Unmarshal(posts[0].body, &payload); err != nil || !payload.Missed {
+					t.Errorf("Expected missed payload but got %s, %v", posts[0].body, err)
+				}
+			}
+			if handler.Pending() != 0 {
+				t.Errorf("Expected empty outbox but got %v pending", handler.Pending())
+			}
+		})
+	}
+}
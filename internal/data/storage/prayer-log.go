@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// PrayerLogStorage keeps log of prayed prayers
+type PrayerLogStorage interface {
+	SavePrayerLog(log models.PrayerLogDto) error
+	LoadPrayerLog(log *models.PrayerLogDto) error
+}
+
+type PrayerLogFileStorage struct {
+	FileName string
+}
+
+// DefaultPrayerLogStorage keeps log in prayer-log.json, next to year files
+func DefaultPrayerLogStorage() PrayerLogStorage {
+	return &PrayerLogFileStorage{
+		FileName: "prayer-log.json",
+	}
+}
+
+// SavePrayerLog replaces log through a temporary file, so a crash while
+// saving does not lose logged prayers
+func (s *PrayerLogFileStorage) SavePrayerLog(log models.PrayerLogDto) error {
+	filePath, err := getOrCreateFilePath(s.FileName)
+	if err != nil {
+		return err
+	}
+
+	fileData, err := json.MarshalIndent(log, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filePath, fileData)
+}
+
+// LoadPrayerLog leaves @log empty if nothing was logged yet
+func (s *PrayerLogFileStorage) LoadPrayerLog(log *models.PrayerLogDto) error {
+	filePath, err := getOrCreateFilePath(s.FileName)
+	if err != nil {
+		return err
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(fileData, log)
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// SnapshotStorage keeps a tiny snapshot of upcoming prayer times
+type SnapshotStorage interface {
+	SaveSnapshot(snapshot models.SnapshotDto) error
+	LoadSnapshot(snapshot *models.SnapshotDto) error
+}
+
+type SnapshotFileStorage struct {
+	FileName string
+}
+
+// DefaultSnapshotStorage keeps snapshot in snapshot.json, next to year files
+func DefaultSnapshotStorage() SnapshotStorage {
+	return &SnapshotFileStorage{
+		FileName: "snapshot.json",
+	}
+}
+
+// SaveSnapshot writes to a temporary file first then renames it, so readers
+// never see a half written snapshot
+func (s *SnapshotFileStorage) SaveSnapshot(snapshot models.SnapshotDto) error {
+	filePath, err := getOrCreateFilePath(s.FileName)
+	if err != nil {
+		return err
+	}
+
+	fileData, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filePath, fileData)
+}
+
+func (s *SnapshotFileStorage) LoadSnapshot(snapshot *models.SnapshotDto) error {
+	filePath, err := getOrCreateFilePath(s.FileName)
+	if err != nil {
+		return err
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(fileData, snapshot)
+}
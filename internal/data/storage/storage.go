@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -14,10 +15,32 @@ type Storage interface {
 	Load(data *models.PrayerTimesResponse) error
 }
 
+// StorageProvider returns storage holding data of @year
+type StorageProvider func(year int) Storage
+
 type FileStorage struct {
 	FileName string
 }
 
+// YearFileStorage is a @StorageProvider that keeps each year data in its own
+// file named after the year, e.g. 2025.json
+func YearFileStorage(year int) Storage {
+	return &FileStorage{
+		FileName: YearFileName(year),
+	}
+}
+
+// YearFileName is the file @YearFileStorage keeps data of @year in
+func YearFileName(year int) string {
+	return fmt.Sprintf("%v.json", year)
+}
+
+// FilePath returns full path of @filename inside root dir, creating the dir
+// if needed. File itself may not exist
+func FilePath(filename string) (string, error) {
+	return getOrCreateFilePath(filename)
+}
+
 // Save given data to file
 //
 // @Returns:
@@ -58,6 +81,25 @@ func (s *FileStorage) Load(data *models.PrayerTimesResponse) error {
 	return json.Unmarshal(fileData, data)
 }
 
+// writeFileAtomic writes @data to a temporary file next to @filePath then
+// renames it, so readers never see a half written file
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
 // Create root dir, then a file inside it with given filename
 //
 // @Returns:
@@ -172,3 +172,21 @@ func TestGetOrCreateRootDir_MkdirError(t *testing.T) {
 	// function variable techniques
 	t.Skip("Skipping test for os.MkdirAll error - would require mocking the function")
 }
+
+// TestWriteFileAtomic tests that writeFileAtomic replaces file without
+// leaving temporary files behind
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "prayer-log.json")
+	require.NoError(t, os.WriteFile(filePath, []byte("old"), 0644))
+
+	require.NoError(t, writeFileAtomic(filePath, []byte("new")), "writeFileAtomic should not return an error")
+
+	fileData, err := os.ReadFile(filePath)
+	require.NoError(t, err, "Should be able to read file")
+	assert.Equal(t, "new", string(fileData), "File should be replaced")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "Temporary file should be renamed over file")
+}
@@ -0,0 +1,45 @@
+// Package storagetest has in memory storages, used to avoid touching users
+// files or network in tests
+package storagetest
+
+import (
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// MemoryStorage is an in memory @storage.Storage of a year
+type MemoryStorage struct {
+	Data models.PrayerTimesResponse
+}
+
+var _ storage.Storage = (*MemoryStorage)(nil)
+
+func (s *MemoryStorage) Save(data models.PrayerTimesResponse) error {
+	s.Data = data
+	return nil
+}
+
+func (s *MemoryStorage) Load(data *models.PrayerTimesResponse) error {
+	*data = s.Data
+	return nil
+}
+
+// MemoryPrayerLogStorage is an in memory @storage.PrayerLogStorage, counting
+// saves
+type MemoryPrayerLogStorage struct {
+	Log   models.PrayerLogDto
+	Saves int
+}
+
+var _ storage.PrayerLogStorage = (*MemoryPrayerLogStorage)(nil)
+
+func (s *MemoryPrayerLogStorage) SavePrayerLog(prayerLog models.PrayerLogDto) error {
+	s.Log = prayerLog
+	s.Saves++
+	return nil
+}
+
+func (s *MemoryPrayerLogStorage) LoadPrayerLog(prayerLog *models.PrayerLogDto) error {
+	*prayerLog = s.Log
+	return nil
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	relativeDateRegex = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+	agoDateRegex      = regexp.MustCompile(`^(\d+)([dw]) ago$`)
+	isoDateRegex      = regexp.MustCompile(`^(\d+)-(\d+)-(\d+)$`)
+	slashDateRegex    = regexp.MustCompile(`^(\d+)/(\d+)(?:/(\d+))?$`)
+)
+
+// NewDate creates date at midnight in @loc, or returns error if @year, @month or
+// @day are out of range instead of normalizing them like @time.Date does,
+// e.g. 31/02 is rejected rather than becoming 03/03
+func NewDate(year int, month int, day int, loc *time.Location) (time.Time, error) {
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("invalid month %v, expected 1 to 12", month)
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if day < 1 || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid day %v, %v %v has %v days", day, time.Month(month), year, DaysInMonth(year, time.Month(month)))
+	}
+	return date, nil
+}
+
+// DaysInMonth returns number of days of @month of @year
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// ParseDate parses human friendly date @s relative to @now. Returned date is at
+// midnight in @now location. Supported forms:
+//
+//	today, tomorrow, yesterday
+//	friday, next friday, last friday (short names like fri work too)
+//	+10d, +3w, and 2d ago, 2w ago, or -2d, -2w after -- on command line, as
+//	cobra takes them for flags otherwise
+//	2026-03-20, 20/03, 20/03/2026
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if match := relativeDateRegex.FindStringSubmatch(s); match != nil {
+		return relativeDate(s, today, match[1] == "-", match[2], match[3])
+	}
+	if match := agoDateRegex.FindStringSubmatch(s); match != nil {
+		return relativeDate(s, today, true, match[1], match[2])
+	}
+
+	if date, ok, err := parseWeekday(s, today); ok {
+		return date, err
+	}
+
+	if match := isoDateRegex.FindStringSubmatch(s); match != nil {
+		return NewDate(atoi(match[1]), atoi(match[2]), atoi(match[3]), now.Location())
+	}
+	if match := slashDateRegex.FindStringSubmatch(s); match != nil {
+		year := now.Year()
+		if match[3] != "" {
+			year = atoi(match[3])
+		}
+		return NewDate(year, atoi(match[2]), atoi(match[1]), now.Location())
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, try today, tomorrow, next friday, +3d, 2d ago, 2026-03-20 or 20/03", s)
+}
+
+// relativeDate returns @today moved by @count days, or weeks if @unit is w,
+// back in time if @past is set
+func relativeDate(s string, today time.Time, past bool, count string, unit string) (time.Time, error) {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	if past {
+		n = -n
+	}
+	if unit == "w" {
+		n *= 7
+	}
+	return today.AddDate(0, 0, n), nil
+}
+
+// atoi converts digits matched by a date regex, too long ones become invalid dates
+func atoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// parseWeekday parses "friday", "next friday" and "last friday" relative to @today
+//
+// @Returns
+//   - date
+//   - whether @s is a weekday form at all
+//   - error if it is, but weekday name is unknown
+func parseWeekday(s string, today time.Time) (time.Time, bool, error) {
+	direction, name, found := strings.Cut(s, " ")
+	if !found {
+		direction, name = "", s
+	}
+	if direction != "" && direction != "next" && direction != "last" {
+		return time.Time{}, false, nil
+	}
+
+	weekday, ok := weekdayByName(name)
+	if !ok {
+		if direction == "" {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, true, fmt.Errorf("invalid weekday %q", name)
+	}
+
+	diff := (int(weekday) - int(today.Weekday()) + 7) % 7
+	switch direction {
+	case "next":
+		if diff == 0 {
+			diff = 7
+		}
+	case "last":
+		diff -= 7
+	}
+	return today.AddDate(0, 0, diff), true, nil
+}
+
+func weekdayByName(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		fullName := strings.ToLower(d.String())
+		if name == fullName || name == fullName[:3] {
+			return d, true
+		}
+	}
+	return time.Sunday, false
+}
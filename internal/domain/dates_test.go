@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// Monday
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		dateStr     string
+		expected    time.Time
+		expectError bool
+	}{
+		{name: "Today", dateStr: "today", expected: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "Tomorrow", dateStr: "Tomorrow", expected: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{name: "Yesterday", dateStr: "yesterday", expected: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "Weekday", dateStr: "friday", expected: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{name: "Same weekday is today", dateStr: "monday", expected: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "Next same weekday", dateStr: "next monday", expected: time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)},
+		{name: "Next short weekday", dateStr: "next  fri", expected: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{name: "Last weekday", dateStr: "last friday", expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "Last same weekday", dateStr: "last monday", expected: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "Days ahead", dateStr: "+10d", expected: time.Date(2026, 10, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "Days back", dateStr: "-20d", expected: time.Date(2026, 9, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "Weeks ahead", dateStr: "+2w", expected: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "Days ago", dateStr: "3d ago", expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "Weeks ago", dateStr: "2W  Ago", expected: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "Signed ago", dateStr: "-2w ago", expectError: true},
+		{name: "ISO date", dateStr: "2026-03-20", expected: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{name: "Day and month", dateStr: "20/03", expected: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{name: "Day month and year", dateStr: "1/1/2027", expected: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Invalid ISO day", dateStr: "2026-02-30", expectError: true},
+		{name: "Invalid month", dateStr: "20/13", expectError: true},
+		{name: "ISO date with trailing chars", dateStr: "2026-03-20x", expectError: true},
+		{name: "ISO date with extra part", dateStr: "2026-03-20-1", expectError: true},
+		{name: "Day and month with trailing chars", dateStr: "20/03abc", expectError: true},
+		{name: "Day month and year with trailing chars", dateStr: "20/03/2026 5", expectError: true},
+		{name: "Unknown weekday", dateStr: "next week", expectError: true},
+		{name: "Unknown word", dateStr: "someday", expectError: true},
+		{name: "Unknown unit", dateStr: "+3m", expectError: true},
+		{name: "Empty string", dateStr: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDate(tt.dateStr, now)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got %v", result)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !tt.expectError && !result.Equal(tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNewDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		year        int
+		month       int
+		day         int
+		expectError bool
+	}{
+		{name: "Valid date", year: 2026, month: 3, day: 20},
+		{name: "Leap day", year: 2024, month: 2, day: 29},
+		{name: "Leap day in non leap year", year: 2026, month: 2, day: 29, expectError: true},
+		{name: "31st of february", year: 2026, month: 2, day: 31, expectError: true},
+		{name: "Month 13", year: 2026, month: 13, day: 1, expectError: true},
+		{name: "Month 0", year: 2026, month: 0, day: 1, expectError: true},
+		{name: "Day 0", year: 2026, month: 1, day: 0, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewDate(tt.year, tt.month, tt.day, time.UTC)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got %v", result)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !tt.expectError && (result.Year() != tt.year || int(result.Month()) != tt.month || result.Day() != tt.day) {
+				t.Errorf("Expected %v-%v-%v but got %v", tt.year, tt.month, tt.day, result)
+			}
+		})
+	}
+}
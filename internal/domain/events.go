@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+type hijriEvent struct {
+	Month int
+	Day   int
+	Event Event
+}
+
+// builtinHijriEvents are used when data source does not provide events
+var builtinHijriEvents = []hijriEvent{
+	{Month: 1, Day: 1, Event: Event{En: "Islamic New Year", Ar: "رأس السنة الهجرية"}},
+	{Month: 1, Day: 10, Event: Event{En: "Ashura", Ar: "عاشوراء"}},
+	{Month: 3, Day: 12, Event: Event{En: "Mawlid al-Nabi", Ar: "المولد النبوي الشريف"}},
+	{Month: 7, Day: 27, Event: Event{En: "Isra and Mi'raj", Ar: "الإسراء والمعراج"}},
+	{Month: 8, Day: 15, Event: Event{En: "Mid-Sha'ban", Ar: "ليلة النصف من شعبان"}},
+	{Month: 9, Day: 1, Event: Event{En: "First day of Ramadan", Ar: "أول رمضان"}},
+	{Month: 9, Day: 27, Event: Event{En: "Laylat al-Qadr", Ar: "ليلة القدر"}},
+	{Month: 10, Day: 1, Event: Event{En: "Eid al-Fitr", Ar: "عيد الفطر"}},
+	{Month: 12, Day: 9, Event: Event{En: "Day of Arafah", Ar: "يوم عرفة"}},
+	{Month: 12, Day: 10, Event: Event{En: "Eid al-Adha", Ar: "عيد الأضحى"}},
+}
+
+// builtinEventFor returns built in event falling on @hijri date, if any
+func builtinEventFor(hijri HijriDate) Event {
+	for _, e := range builtinHijriEvents {
+		if e.Month == hijri.Month && e.Day == hijri.Day {
+			return e.Event
+		}
+	}
+	return Event{}
+}
+
+// DaysBetween returns number of calendar days from @from to @to, ignoring time of day
+func DaysBetween(from time.Time, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type HijriLanguage string
+
+const (
+	HijriLanguageEn HijriLanguage = "en"
+	HijriLanguageAr HijriLanguage = "ar"
+)
+
+// hijriEpoch is the julian day number of 1 Muharram 1 AH (16 July 622, julian calendar)
+const hijriEpoch = 1948440
+
+var (
+	hijriMonthNamesEn = []string{
+		"Muharram",
+		"Safar",
+		"Rabi' al-Awwal",
+		"Rabi' al-Thani",
+		"Jumada al-Ula",
+		"Jumada al-Akhirah",
+		"Rajab",
+		"Sha'ban",
+		"Ramadan",
+		"Shawwal",
+		"Dhu al-Qi'dah",
+		"Dhu al-Hijjah",
+	}
+	hijriMonthNamesAr = []string{
+		"محرم",
+		"صفر",
+		"ربيع الأول",
+		"ربيع الآخر",
+		"جمادى الأولى",
+		"جمادى الآخرة",
+		"رجب",
+		"شعبان",
+		"رمضان",
+		"شوال",
+		"ذو القعدة",
+		"ذو الحجة",
+	}
+)
+
+type HijriDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+func (h HijriDate) IsZero() bool {
+	return h.Year == 0 && h.Month == 0 && h.Day == 0
+}
+
+// MonthName returns hijri month name in given @lang, falls back to
+// transliterated english names
+func (h HijriDate) MonthName(lang HijriLanguage) string {
+	if h.Month < 1 || h.Month > 12 {
+		return ""
+	}
+	if lang == HijriLanguageAr {
+		return hijriMonthNamesAr[h.Month-1]
+	}
+	return hijriMonthNamesEn[h.Month-1]
+}
+
+// Format returns date like "27 Ramadan 1447 AH" or "27 رمضان 1447 هـ"
+func (h HijriDate) Format(lang HijriLanguage) string {
+	if h.IsZero() {
+		return ""
+	}
+	if lang == HijriLanguageAr {
+		return fmt.Sprintf("%v %v %v هـ", h.Day, h.MonthName(lang), h.Year)
+	}
+	return fmt.Sprintf("%v %v %v AH", h.Day, h.MonthName(lang), h.Year)
+}
+
+// String returns date in numeric form "1447-09-27"
+func (h HijriDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", h.Year, h.Month, h.Day)
+}
+
+// ParseHijriDate parses hijri dates written as "27/09/1447" (same layout the
+// dataset uses for gregorian dates) or "1447-09-27"
+func ParseHijriDate(s string) (HijriDate, error) {
+	s = strings.TrimSpace(s)
+
+	var h HijriDate
+	var err error
+	if strings.Contains(s, "/") {
+		_, err = fmt.Sscanf(s, "%d/%d/%d", &h.Day, &h.Month, &h.Year)
+	} else {
+		_, err = fmt.Sscanf(s, "%d-%d-%d", &h.Year, &h.Month, &h.Day)
+	}
+	if err != nil {
+		return HijriDate{}, fmt.Errorf("invalid hijri date %q", s)
+	}
+	if h.Month < 1 || h.Month > 12 || h.Day < 1 || h.Day > 30 || h.Year < 1 {
+		return HijriDate{}, fmt.Errorf("invalid hijri date %q", s)
+	}
+	return h, nil
+}
+
+// HijriFromGregorian converts @date to hijri using the tabular (arithmetic)
+// islamic calendar, shifted by @offset days. Real calendars depend on moon
+// sighting, so the result may differ by a day or two, hence the offset
+func HijriFromGregorian(date time.Time, offset int) HijriDate {
+	jdn := gregorianToJDN(date.Year(), int(date.Month()), date.Day()) + offset
+
+	year := (30*(jdn-hijriEpoch) + 10646) / 10631
+	month := min(12, (2*(jdn-29-hijriToJDN(year, 1, 1))+58)/59+1)
+	day := jdn - hijriToJDN(year, month, 1) + 1
+	return HijriDate{Year: year, Month: month, Day: day}
+}
+
+// GregorianFromHijri is the inverse of @HijriFromGregorian. Returned date is at
+// midnight in @loc
+func GregorianFromHijri(h HijriDate, offset int, loc *time.Location) time.Time {
+	jdn := hijriToJDN(h.Year, h.Month, h.Day) - offset
+	year, month, day := jdnToGregorian(jdn)
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
+
+func hijriToJDN(year, month, day int) int {
+	return day + (59*(month-1)+1)/2 + (year-1)*354 + (3+11*year)/30 + hijriEpoch - 1
+}
+
+func gregorianToJDN(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+}
+
+func jdnToGregorian(jdn int) (int, int, int) {
+	a := jdn + 32044
+	b := (4*a + 3) / 146097
+	c := a - 146097*b/4
+	d := (4*c + 3) / 1461
+	e := c - 1461*d/4
+	m := (5*e + 2) / 153
+
+	day := e - (153*m+2)/5 + 1
+	month := m + 3 - 12*(m/10)
+	year := 100*b + d - 4800 + m/10
+	return year, month, day
+}
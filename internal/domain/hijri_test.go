@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHijriDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		hijriStr    string
+		expected    HijriDate
+		expectError bool
+	}{
+		{
+			name:     "Slash separated date",
+			hijriStr: "27/09/1447",
+			expected: HijriDate{Year: 1447, Month: 9, Day: 27},
+		},
+		{
+			name:     "Dash separated date",
+			hijriStr: "1447-09-27",
+			expected: HijriDate{Year: 1447, Month: 9, Day: 27},
+		},
+		{
+			name:     "Date with extra whitespace",
+			hijriStr: "  1/1/1446 ",
+			expected: HijriDate{Year: 1446, Month: 1, Day: 1},
+		},
+		{
+			name:        "Empty string",
+			hijriStr:    "",
+			expectError: true,
+		},
+		{
+			name:        "Invalid month",
+			hijriStr:    "1447-13-01",
+			expectError: true,
+		},
+		{
+			name:        "Invalid day",
+			hijriStr:    "31/01/1447",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseHijriDate(tt.hijriStr)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !tt.expectError && result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestHijriFromGregorian(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		offset   int
+		expected HijriDate
+	}{
+		{
+			name:     "Hijri epoch",
+			date:     time.Date(622, 7, 19, 0, 0, 0, 0, time.UTC),
+			expected: HijriDate{Year: 1, Month: 1, Day: 1},
+		},
+		{
+			name:     "First of ramadan",
+			date:     time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+			expected: HijriDate{Year: 1445, Month: 9, Day: 1},
+		},
+		{
+			name:     "Eid al-fitr",
+			date:     time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+			expected: HijriDate{Year: 1447, Month: 10, Day: 1},
+		},
+		{
+			name:     "Negative offset crosses month",
+			date:     time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+			offset:   -1,
+			expected: HijriDate{Year: 1447, Month: 9, Day: 30},
+		},
+		{
+			name:     "Positive offset",
+			date:     time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC),
+			offset:   1,
+			expected: HijriDate{Year: 1446, Month: 12, Day: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HijriFromGregorian(tt.date, tt.offset)
+			if result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+
+			back := GregorianFromHijri(result, tt.offset, time.UTC)
+			if !SameDay(back, tt.date) {
+				t.Errorf("Expected round trip to %v but got %v", tt.date, back)
+			}
+		})
+	}
+}
+
+func TestHijriDateFormat(t *testing.T) {
+	hijri := HijriDate{Year: 1447, Month: 9, Day: 27}
+
+	if result := hijri.Format(HijriLanguageEn); result != "27 Ramadan 1447 AH" {
+		t.Errorf("Unexpected english format: %v", result)
+	}
+	if result := hijri.Format(HijriLanguageAr); result != "27 رمضان 1447 هـ" {
+		t.Errorf("Unexpected arabic format: %v", result)
+	}
+	if result := (HijriDate{}).Format(HijriLanguageEn); result != "" {
+		t.Errorf("Expected empty format for zero date, got: %v", result)
+	}
+}
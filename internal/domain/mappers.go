@@ -18,10 +18,30 @@ func mapToDayPrayer(prayerTimes models.DailyPrayersDto) *DayPrayers {
 		return nil
 	}
 
+	// hijri date is optional, left empty when missing so the converter can fill it
+	hijri, _ := ParseHijriDate(prayerTimes.Hijri)
+
+	// sunrise is optional too, not all data sources have it
+	sunrise, _ := parseTime(day, prayerTimes.Prayers.Sunrise)
+
 	return &DayPrayers{
 		ID:      prayerTimes.ID,
+		WeekID:  prayerTimes.WeekID,
 		Date:    day,
+		Hijri:   hijri,
+		Event:   Event{En: strings.TrimSpace(prayerTimes.Event.En), Ar: strings.TrimSpace(prayerTimes.Event.Ar)},
 		Prayers: prayers,
+		Sunrise: sunrise,
+	}
+}
+
+func mapToDailyPrayerSchedule(dayPrayers DayPrayers) DailyPrayerSchedule {
+	return DailyPrayerSchedule{
+		Date:    dayPrayers.Date,
+		Hijri:   dayPrayers.Hijri,
+		Event:   dayPrayers.Event,
+		Prayers: dayPrayers.Prayers,
+		Sunrise: dayPrayers.Sunrise,
 	}
 }
 
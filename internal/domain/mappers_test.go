@@ -11,14 +11,17 @@ func TestMapToDayPrayer(t *testing.T) {
 	day := time.Date(2025, 5, 28, 1, 0, 0, 0, time.Now().Location())
 	prayers := models.PrayerTimesDto{
 		Fajr:    "05:00 am",
+		Sunrise: "06:15 am",
 		Dhuhr:   "11:00 am",
 		Asr:     "01:00 pm",
 		Maghrib: "05:00 pm",
 		Isha:    "07:00 pm",
 	}
 	dayPrayers := DayPrayers{
-		ID:   1,
-		Date: day,
+		ID:      1,
+		Date:    day,
+		Hijri:   HijriDate{Year: 1446, Month: 12, Day: 1},
+		Sunrise: time.Date(day.Year(), day.Month(), day.Day(), 6, 15, 0, 0, day.Location()),
 		Prayers: []Prayer{
 			{
 				Name: "Fajr",
@@ -80,6 +83,7 @@ func TestMapToDayPrayer(t *testing.T) {
 			dailyPrayerDto: models.DailyPrayersDto{
 				ID:        1,
 				Gregorian: "28/05/2025",
+				Hijri:     "01/12/1446",
 				Prayers:   prayers,
 			},
 			expectedDayPrayers: &dayPrayers,
@@ -102,6 +106,14 @@ func TestMapToDayPrayer(t *testing.T) {
 				t.Errorf("Expected ID=%v, got=%v", tt.expectedDayPrayers.ID, result.ID)
 			}
 
+			if !result.Sunrise.Equal(tt.expectedDayPrayers.Sunrise) {
+				t.Errorf("Expected Sunrise=%v, got=%v", tt.expectedDayPrayers.Sunrise, result.Sunrise)
+			}
+
+			if result.Hijri != tt.expectedDayPrayers.Hijri {
+				t.Errorf("Expected Hijri=%v, got=%v", tt.expectedDayPrayers.Hijri, result.Hijri)
+			}
+
 			if !SameDay(result.Date, tt.expectedDayPrayers.Date) {
 				t.Errorf("Expected Date=%v, got=%v", tt.expectedDayPrayers.Date, result.Date)
 			}
@@ -7,21 +7,67 @@ type Prayer struct {
 	Time time.Time
 }
 
+type Event struct {
+	En string
+	Ar string
+}
+
+func (e Event) IsZero() bool {
+	return e.En == "" && e.Ar == ""
+}
+
+// Name returns event name in @lang, or in the other language if missing
+func (e Event) Name(lang HijriLanguage) string {
+	if lang == HijriLanguageAr && e.Ar != "" || e.En == "" {
+		return e.Ar
+	}
+	return e.En
+}
+
 type DayPrayers struct {
 	ID      int
+	WeekID  int
 	Date    time.Time
+	Hijri   HijriDate
+	Event   Event
 	Prayers []Prayer
+
+	// Sunrise is zero if data source does not provide it
+	Sunrise time.Time
 }
 
 type DailyPrayerSchedule struct {
 	Date    time.Time
+	Hijri   HijriDate
+	Event   Event
 	Prayers []Prayer
+
+	// Sunrise is zero if data source does not provide it
+	Sunrise time.Time
+}
+
+// DayEvent is an islamic occasion and the day it falls on
+type DayEvent struct {
+	Date  time.Time
+	Hijri HijriDate
+	Event Event
 }
 
 type ActivePrayerTracking struct {
 	DailyPrayerSchedule
-	PreviousPrayer string
-	NextPrayer     string
-	TimeRemaining  time.Duration
-	Progress       float64
+	PreviousPrayer     string
+	PreviousPrayerTime time.Time
+	NextPrayer         string
+	NextPrayerTime     time.Time
+	TimeRemaining      time.Duration
+	Progress           float64
+}
+
+// At returns tracking with time remaining and progress as of @now. Previous
+// and next prayers are kept, so tracking has to be fetched again once @now
+// reaches next prayer
+func (t ActivePrayerTracking) At(now time.Time) ActivePrayerTracking {
+	t.TimeRemaining = max(t.NextPrayerTime.Sub(now), 0)
+	t.Progress = timeProgressPercent(t.PreviousPrayerTime, t.NextPrayerTime, now)
+	return t
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// NightMarkers are times of the islamic night, which runs from maghrib to
+// next day fajr
+type NightMarkers struct {
+	Maghrib time.Time
+
+	// Midnight is half way between maghrib and fajr, end of isha time
+	Midnight time.Time
+
+	// LastThird starts last third of the night, time of qiyam
+	LastThird time.Time
+
+	Fajr time.Time
+}
+
+// GetNightMarkers returns night starting at maghrib of @today and ending at
+// fajr of @tomorrow, or false if either prayer is missing
+func GetNightMarkers(today DailyPrayerSchedule, tomorrow DailyPrayerSchedule) (NightMarkers, bool) {
+	maghrib, ok := FindPrayer(today.Prayers, models.SortedPrayerNames[3])
+	if !ok {
+		return NightMarkers{}, false
+	}
+	fajr, ok := FindPrayer(tomorrow.Prayers, models.SortedPrayerNames[0])
+	if !ok || !fajr.After(maghrib) {
+		return NightMarkers{}, false
+	}
+
+	night := fajr.Sub(maghrib)
+	return NightMarkers{
+		Maghrib:   maghrib,
+		Midnight:  maghrib.Add(night / 2),
+		LastThird: maghrib.Add(night * 2 / 3),
+		Fajr:      fajr,
+	}, true
+}
+
+// FindPrayer returns time of prayer named @name in @prayers
+func FindPrayer(prayers []Prayer, name string) (time.Time, bool) {
+	for _, p := range prayers {
+		if p.Name == name {
+			return p.Time, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNightMarkers(t *testing.T) {
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	today := DailyPrayerSchedule{Prayers: []Prayer{{Name: "Fajr", Time: at(20, 5, 0)}, {Name: "Maghrib", Time: at(20, 18, 0)}}}
+	tomorrow := DailyPrayerSchedule{Prayers: []Prayer{{Name: "Fajr", Time: at(21, 4, 30)}, {Name: "Maghrib", Time: at(21, 18, 1)}}}
+
+	tests := []struct {
+		name      string
+		today     DailyPrayerSchedule
+		tomorrow  DailyPrayerSchedule
+		expected  NightMarkers
+		expectsOk bool
+	}{
+		{
+			name:      "Night of 10h30m",
+			today:     today,
+			tomorrow:  tomorrow,
+			expected:  NightMarkers{Maghrib: at(20, 18, 0), Midnight: at(20, 23, 15), LastThird: at(21, 1, 0), Fajr: at(21, 4, 30)},
+			expectsOk: true,
+		},
+		{name: "Missing maghrib", today: DailyPrayerSchedule{Prayers: today.Prayers[:1]}, tomorrow: tomorrow},
+		{name: "Missing tomorrow fajr", today: today, tomorrow: DailyPrayerSchedule{}},
+		{name: "Days out of order", today: tomorrow, tomorrow: today},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := GetNightMarkers(tt.today, tt.tomorrow)
+			if ok != tt.expectsOk {
+				t.Fatalf("Expected ok %v but got %v", tt.expectsOk, ok)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// PrayerLog records which prayers of each day were prayed
+type PrayerLog struct {
+	storage storage.PrayerLogStorage
+	days    map[string][]string
+}
+
+// LoadPrayerLog reads log kept in @prayerLogStorage, changes are saved back to it
+func LoadPrayerLog(prayerLogStorage storage.PrayerLogStorage) (*PrayerLog, error) {
+	var dto models.PrayerLogDto
+	if err := prayerLogStorage.LoadPrayerLog(&dto); err != nil {
+		return nil, err
+	}
+	if dto.Days == nil {
+		dto.Days = map[string][]string{}
+	}
+	return &PrayerLog{storage: prayerLogStorage, days: dto.Days}, nil
+}
+
+// Prayed checks if prayer named @name of @date is logged
+func (l *PrayerLog) Prayed(date time.Time, name string) bool {
+	return slices.Contains(l.days[date.Format(time.DateOnly)], name)
+}
+
+// Count returns number of prayers logged on @date
+func (l *PrayerLog) Count(date time.Time) int {
+	return len(l.days[date.Format(time.DateOnly)])
+}
+
+// Toggle marks prayer named @name of @date as prayed, or unmarks it if it is
+// already marked, then saves log
+func (l *PrayerLog) Toggle(date time.Time, name string) error {
+	key := date.Format(time.DateOnly)
+	prayers := l.days[key]
+	if i := slices.Index(prayers, name); i >= 0 {
+		prayers = slices.Delete(prayers, i, i+1)
+	} else {
+		prayers = append(prayers, name)
+	}
+
+	if len(prayers) == 0 {
+		delete(l.days, key)
+	} else {
+		l.days[key] = prayers
+	}
+	return l.storage.SavePrayerLog(models.PrayerLogDto{Days: l.days})
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage/storagetest"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+func TestPrayerLogToggle(t *testing.T) {
+	prayerLogStorage := &storagetest.MemoryPrayerLogStorage{Log: models.PrayerLogDto{Days: map[string][]string{"2026-03-19": {"Fajr"}}}}
+	prayerLog, err := LoadPrayerLog(prayerLogStorage)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	yesterday := time.Date(2026, 3, 19, 0, 0, 0, 0, time.UTC)
+	today := yesterday.AddDate(0, 0, 1)
+	if !prayerLog.Prayed(yesterday, "Fajr") || prayerLog.Count(today) != 0 {
+		t.Fatalf("Expected only fajr of yesterday to be logged")
+	}
+
+	for _, name := range []string{"Fajr", "Dhuhr", "Fajr"} {
+		if err := prayerLog.Toggle(today, name); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+	if prayerLog.Prayed(today, "Fajr") || !prayerLog.Prayed(today, "Dhuhr") || prayerLog.Count(today) != 1 {
+		t.Errorf("Expected only dhuhr of today to be logged but got %v", prayerLogStorage.Log.Days)
+	}
+	if prayerLogStorage.Saves != 3 {
+		t.Errorf("Expected every toggle to be saved but got %v saves", prayerLogStorage.Saves)
+	}
+
+	if err := prayerLog.Toggle(today, "Dhuhr"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, ok := prayerLogStorage.Log.Days["2026-03-20"]; ok {
+		t.Errorf("Expected empty day to be removed but got %v", prayerLogStorage.Log.Days)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
@@ -19,15 +22,46 @@ func SameDay(t time.Time, otherT time.Time) bool {
 type PrayerTimesRepo interface {
 	GetDailyPrayerSchedule(date time.Time) (DailyPrayerSchedule, error)
 	GetActivePrayerTracking(date time.Time) (ActivePrayerTracking, error)
+	FindHijriDate(hijri HijriDate) (time.Time, error)
+	GetEvents(year int) ([]DayEvent, error)
+	GetWeekPrayerSchedules(date time.Time) ([]DailyPrayerSchedule, error)
+	GetMonthPrayerSchedules(year int, month time.Month) ([]DailyPrayerSchedule, error)
+	GetSchedules(from time.Time, to time.Time) iter.Seq2[DailyPrayerSchedule, error]
+	GetDataVersion(year int) (string, error)
+}
+
+// HijriOptions controls how hijri dates are resolved
+type HijriOptions struct {
+	// Offset in days applied to converted hijri dates, used only when data
+	// source does not provide hijri dates
+	Offset int
+
+	// AdvanceAfterMaghrib starts the next islamic day at maghrib instead of midnight
+	AdvanceAfterMaghrib bool
 }
 
 type PrayerTimesRepoImpl struct {
-	storage storage.Storage
+	storageProvider storage.StorageProvider
+	snapshotStorage storage.SnapshotStorage
+	hijriOptions    HijriOptions
+	snapshotWritten bool
+
+	// years data already loaded, so each year is read once per repo
+	years map[int]*models.PrayerTimesResponse
 }
 
-func CreatePrayerTimesRepo(s storage.Storage) PrayerTimesRepo {
+// CreatePrayerTimesRepo creates repo reading year data from @storageProvider.
+// Snapshot of today and tomorrow is kept in @snapshotStorage, unless it is nil
+func CreatePrayerTimesRepo(
+	storageProvider storage.StorageProvider,
+	snapshotStorage storage.SnapshotStorage,
+	hijriOptions HijriOptions,
+) PrayerTimesRepo {
 	return &PrayerTimesRepoImpl{
-		storage: s,
+		storageProvider: storageProvider,
+		snapshotStorage: snapshotStorage,
+		hijriOptions:    hijriOptions,
+		years:           map[int]*models.PrayerTimesResponse{},
 	}
 }
 
@@ -37,10 +71,7 @@ func (r *PrayerTimesRepoImpl) GetDailyPrayerSchedule(date time.Time) (DailyPraye
 		return DailyPrayerSchedule{}, errors.New("Failed to get day prayer")
 	}
 
-	return DailyPrayerSchedule{
-		Date:    dayPrayers.Date,
-		Prayers: dayPrayers.Prayers,
-	}, nil
+	return mapToDailyPrayerSchedule(*dayPrayers), nil
 }
 
 func (r *PrayerTimesRepoImpl) GetActivePrayerTracking(date time.Time) (ActivePrayerTracking, error) {
@@ -59,49 +90,279 @@ func (r *PrayerTimesRepoImpl) GetActivePrayerTracking(date time.Time) (ActivePra
 		return ActivePrayerTracking{}, errors.New("Failed to get time remaining to next prayer")
 	}
 
-	timeProgressPercent := timeProgressPercent(previousPrayer.Time, nextPrayer.Time)
+	timeProgressPercent := timeProgressPercent(previousPrayer.Time, nextPrayer.Time, time.Now())
+
+	hijri := dayPrayers.Hijri
+	if r.hijriOptions.AdvanceAfterMaghrib && afterMaghrib(*dayPrayers, time.Now()) {
+		tomorrowPrayers := r.getDayPrayerTimeFor(dayPrayers.Date.AddDate(0, 0, 1))
+		if tomorrowPrayers != nil {
+			hijri = tomorrowPrayers.Hijri
+		}
+	}
 
 	return ActivePrayerTracking{
 		DailyPrayerSchedule: DailyPrayerSchedule{
 			Date:    dayPrayers.Date,
+			Hijri:   hijri,
+			Event:   dayPrayers.Event,
 			Prayers: dayPrayers.Prayers,
+			Sunrise: dayPrayers.Sunrise,
 		},
-		PreviousPrayer: previousPrayer.Name,
-		NextPrayer:     nextPrayer.Name,
-		TimeRemaining:  *reminaingToNextPrayer,
-		Progress:       timeProgressPercent,
+		PreviousPrayer:     previousPrayer.Name,
+		PreviousPrayerTime: previousPrayer.Time,
+		NextPrayer:         nextPrayer.Name,
+		NextPrayerTime:     nextPrayer.Time,
+		TimeRemaining:      *reminaingToNextPrayer,
+		Progress:           timeProgressPercent,
 	}, nil
 }
 
-func (r *PrayerTimesRepoImpl) loadFromLocal() *models.PrayerTimesResponse {
+// FindHijriDate returns gregorian date matching @hijri. Starts from converter
+// estimate then looks at days around it since data source hijri dates are
+// based on moon sighting. Falls back to estimate if data has no matching day
+func (r *PrayerTimesRepoImpl) FindHijriDate(hijri HijriDate) (time.Time, error) {
+	if hijri.IsZero() {
+		return time.Time{}, errors.New("Hijri date is empty")
+	}
+
+	estimate := GregorianFromHijri(hijri, r.hijriOptions.Offset, time.Local)
+	for _, delta := range []int{0, -1, 1, -2, 2} {
+		dayPrayers := r.getDayPrayerTimeFor(estimate.AddDate(0, 0, delta))
+		if dayPrayers != nil && dayPrayers.Hijri == hijri {
+			return dayPrayers.Date, nil
+		}
+	}
+	return estimate, nil
+}
+
+// GetEvents returns all events of @year sorted by date
+func (r *PrayerTimesRepoImpl) GetEvents(year int) ([]DayEvent, error) {
+	yearPrayers, err := r.getYearDayPrayers(year)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []DayEvent{}
+	for _, dayPrayers := range yearPrayers {
+		if dayPrayers.Event.IsZero() {
+			continue
+		}
+		events = append(events, DayEvent{
+			Date:  dayPrayers.Date,
+			Hijri: dayPrayers.Hijri,
+			Event: dayPrayers.Event,
+		})
+	}
+	return events, nil
+}
+
+// GetWeekPrayerSchedules returns schedules of the week @date falls in. Days are
+// grouped by data source week id, or by ISO week if data source has none,
+// taking days of neighbouring year when ISO week crosses year boundary
+func (r *PrayerTimesRepoImpl) GetWeekPrayerSchedules(date time.Time) ([]DailyPrayerSchedule, error) {
+	yearPrayers, err := r.getYearDayPrayers(date.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	weekID := 0
+	for _, dayPrayers := range yearPrayers {
+		if SameDay(dayPrayers.Date, date) {
+			weekID = dayPrayers.WeekID
+			break
+		}
+	}
+
+	if weekID == 0 {
+		// ISO week may start in previous year or end in next one
+		monday := date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+		for _, day := range []time.Time{monday, monday.AddDate(0, 0, 6)} {
+			if day.Year() == date.Year() {
+				continue
+			}
+			if neighbourPrayers, err := r.getYearDayPrayers(day.Year()); err == nil {
+				yearPrayers = append(yearPrayers, neighbourPrayers...)
+			}
+		}
+		sort.Slice(yearPrayers, func(i, j int) bool {
+			return yearPrayers[i].Date.Before(yearPrayers[j].Date)
+		})
+	}
+
+	year, week := date.ISOWeek()
+	schedules := []DailyPrayerSchedule{}
+	for _, dayPrayers := range yearPrayers {
+		dayYear, dayWeek := dayPrayers.Date.ISOWeek()
+		if weekID != 0 && dayPrayers.WeekID != weekID || weekID == 0 && (dayYear != year || dayWeek != week) {
+			continue
+		}
+		schedules = append(schedules, mapToDailyPrayerSchedule(dayPrayers))
+	}
+
+	if len(schedules) == 0 {
+		return nil, errors.New("Failed to get week prayers")
+	}
+	return schedules, nil
+}
+
+// GetMonthPrayerSchedules returns schedules of all days of @month. Year data
+// is loaded once for the whole month
+func (r *PrayerTimesRepoImpl) GetMonthPrayerSchedules(year int, month time.Month) ([]DailyPrayerSchedule, error) {
+	yearPrayers, err := r.getYearDayPrayers(year)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []DailyPrayerSchedule{}
+	for _, dayPrayers := range yearPrayers {
+		if dayPrayers.Date.Month() == month {
+			schedules = append(schedules, mapToDailyPrayerSchedule(dayPrayers))
+		}
+	}
+
+	if len(schedules) == 0 {
+		return nil, errors.New("Failed to get month prayers")
+	}
+	return schedules, nil
+}
+
+// GetSchedules iterates over schedules of each day from @from to @to inclusive.
+// Days may span multiple years, each year data is loaded once when reached.
+// Iteration stops after first error
+func (r *PrayerTimesRepoImpl) GetSchedules(from time.Time, to time.Time) iter.Seq2[DailyPrayerSchedule, error] {
+	return func(yield func(DailyPrayerSchedule, error) bool) {
+		day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+		for ; !day.After(to); day = day.AddDate(0, 0, 1) {
+			dayPrayers := r.getDayPrayerTimeFor(day)
+			if dayPrayers == nil {
+				yield(DailyPrayerSchedule{}, fmt.Errorf("Failed to get day prayer of %v", formatDate(day)))
+				return
+			}
+			if !yield(mapToDailyPrayerSchedule(*dayPrayers), nil) {
+				return
+			}
+		}
+	}
+}
+
+// GetDataVersion returns data source hash of @year data, it changes whenever
+// data source updates that year
+func (r *PrayerTimesRepoImpl) GetDataVersion(year int) (string, error) {
+	data := r.getYearData(year)
+	if data == nil {
+		return "", errors.New("Failed to get year prayers")
+	}
+	return data.Sha1, nil
+}
+
+// getYearDayPrayers returns all days of @year sorted by date
+func (r *PrayerTimesRepoImpl) getYearDayPrayers(year int) ([]DayPrayers, error) {
+	data := r.getYearData(year)
+	if data == nil {
+		return nil, errors.New("Failed to get year prayers")
+	}
+
+	dataHasEvents := hasEvents(*data)
+	yearPrayers := []DayPrayers{}
+	for _, prayerTimes := range data.Year {
+		dayPrayers := r.mapDayPrayers(prayerTimes, dataHasEvents)
+		if dayPrayers == nil || dayPrayers.Date.Year() != year {
+			continue
+		}
+		yearPrayers = append(yearPrayers, *dayPrayers)
+	}
+
+	sort.Slice(yearPrayers, func(i, j int) bool {
+		return yearPrayers[i].Date.Before(yearPrayers[j].Date)
+	})
+	return yearPrayers, nil
+}
+
+func (r *PrayerTimesRepoImpl) loadFromLocal(year int) *models.PrayerTimesResponse {
 	var data models.PrayerTimesResponse
-	err := (*r).storage.Load(&data)
+	err := r.storageProvider(year).Load(&data)
 	if err != nil {
 		return nil
 	}
 	return &data
 }
 
-// getDayPrayerTimeFor get caches data locally or fetch new data from remote then save locally.
-// Then search data for specific @year @month and @day. If found return prayer times
-func (r *PrayerTimesRepoImpl) getDayPrayerTimeFor(time time.Time) *DayPrayers {
-	dateStr := formatDate(time)
+// getYearData get caches data locally or fetch new data of @year from remote then save locally.
+func (r *PrayerTimesRepoImpl) getYearData(year int) *models.PrayerTimesResponse {
+	if data, ok := r.years[year]; ok {
+		return data
+	}
 
-	data := r.loadFromLocal()
+	data := r.loadFromLocal(year)
 	if data == nil {
-		res, err := r.fetchAndSavePrayerTimes(time.Year())
+		res, err := r.fetchAndSavePrayerTimes(year)
 		if err != nil {
-			fmt.Println("Failed to fetch data from internet")
+			fmt.Fprintln(os.Stderr, "Failed to fetch data from internet")
 			return nil
 		}
 		data = res
 	}
+	r.years[year] = data
+
+	now := time.Now()
+	if year == now.Year() || year == now.AddDate(0, 0, 1).Year() {
+		r.writeSnapshot(now)
+	}
+	return data
+}
+
+// getDayPrayerTimeFor search year data for specific @year @month and @day. If found return prayer times
+func (r *PrayerTimesRepoImpl) getDayPrayerTimeFor(time time.Time) *DayPrayers {
+	dateStr := formatDate(time)
+
+	data := r.getYearData(time.Year())
+	if data == nil {
+		return nil
+	}
 
 	prayerTimes := getPrayerTimes(*data, dateStr)
 	if prayerTimes == nil {
 		return nil
 	}
-	return mapToDayPrayer(*prayerTimes)
+	return r.mapDayPrayers(*prayerTimes, hasEvents(*data))
+}
+
+// mapDayPrayers maps @prayerTimes and fills what data source is missing:
+// hijri date from converter and events from built in events when
+// @dataHasEvents is false
+func (r *PrayerTimesRepoImpl) mapDayPrayers(prayerTimes models.DailyPrayersDto, dataHasEvents bool) *DayPrayers {
+	dayPrayers := mapToDayPrayer(prayerTimes)
+	if dayPrayers == nil {
+		return nil
+	}
+	if dayPrayers.Hijri.IsZero() {
+		dayPrayers.Hijri = HijriFromGregorian(dayPrayers.Date, r.hijriOptions.Offset)
+	}
+	if !dataHasEvents {
+		dayPrayers.Event = builtinEventFor(dayPrayers.Hijri)
+	}
+	return dayPrayers
+}
+
+// hasEvents checks if data source provides events at all
+func hasEvents(data models.PrayerTimesResponse) bool {
+	for _, dayPrayer := range data.Year {
+		if dayPrayer.Event.En != "" || dayPrayer.Event.Ar != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// afterMaghrib checks if @now is at or after maghrib prayer of @dayPrayers
+func afterMaghrib(dayPrayers DayPrayers, now time.Time) bool {
+	maghrib := models.SortedPrayerNames[3]
+	for _, p := range dayPrayers.Prayers {
+		if p.Name == maghrib {
+			return !now.Before(p.Time)
+		}
+	}
+	return false
 }
 
 // getNextAndPreviousPrayerTimes
@@ -145,19 +406,23 @@ func (r *PrayerTimesRepoImpl) getNextAndPreviousPrayerTimes(dayPrayers DayPrayer
 }
 
 func (r *PrayerTimesRepoImpl) fetchAndSavePrayerTimes(year int) (*models.PrayerTimesResponse, error) {
-	fmt.Println("Fetching data from internet...")
-	fmt.Printf("year=%v\n", year)
+	// status messages go to stderr so stdout stays clean for scripts
+	fmt.Fprintln(os.Stderr, "Fetching data from internet...")
+	fmt.Fprintf(os.Stderr, "year=%v\n", year)
 	res, err := fetchPrayingTimes(year)
 	if err != nil {
 		return nil, err
 	}
-	(*r).storage.Save(*res)
+	r.storageProvider(year).Save(*res)
 	return res, nil
 }
 
+// DataSource is where prayer times data is fetched from, for attribution
+const DataSource = "ibad-al-rahman.github.io/prayer-times"
+
 // TODO: move this to data/api module
 func fetchPrayingTimes(year int) (*models.PrayerTimesResponse, error) {
-	baseUrl := fmt.Sprintf("https://ibad-al-rahman.github.io/prayer-times/v1/year/days/%v.json", year)
+	baseUrl := fmt.Sprintf("https://%v/v1/year/days/%v.json", DataSource, year)
 
 	resp, err := http.Get(baseUrl)
 	if err != nil {
@@ -202,8 +467,8 @@ func getTimeRemainingTo(nextPrayerTime time.Time) *time.Duration {
 func timeProgressPercent(
 	previousPrayerTime time.Time,
 	nextPrayerTime time.Time,
+	now time.Time,
 ) float64 {
-	now := time.Now()
 	totalDuration := nextPrayerTime.Sub(previousPrayerTime).Seconds()
 	passedDuration := nextPrayerTime.Sub(now).Seconds()
 
@@ -222,19 +487,7 @@ func timeProgressPercent(
 }
 
 func formatDate(time time.Time) string {
-	day := time.Day()
-	month := int(time.Month())
-	year := time.Year()
-
-	dayStr := fmt.Sprint(day)
-	if day <= 9 {
-		dayStr = fmt.Sprintf("0%v", day)
-	}
-	monthStr := fmt.Sprint(month)
-	if month < 9 {
-		monthStr = fmt.Sprintf("0%v", month)
-	}
-	return fmt.Sprintf("%v/%v/%v", dayStr, monthStr, year)
+	return fmt.Sprintf("%02d/%02d/%v", time.Day(), int(time.Month()), time.Year())
 }
 
 func getPrayerTimes(
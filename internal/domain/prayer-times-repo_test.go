@@ -0,0 +1,384 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage/storagetest"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// memoryStorageProvider returns @storage.StorageProvider serving given years data
+func memoryStorageProvider(years ...models.PrayerTimesResponse) storage.StorageProvider {
+	storages := map[int]*storagetest.MemoryStorage{}
+	for _, data := range years {
+		date, _ := time.Parse("02/01/2006", data.Year[0].Gregorian)
+		storages[date.Year()] = &storagetest.MemoryStorage{Data: data}
+	}
+	return func(year int) storage.Storage {
+		if s, ok := storages[year]; ok {
+			return s
+		}
+		return &failingStorage{}
+	}
+}
+
+// failingStorage is a @storage.Storage of a year that has no data
+type failingStorage struct{}
+
+func (s *failingStorage) Save(data models.PrayerTimesResponse) error {
+	return nil
+}
+
+func (s *failingStorage) Load(data *models.PrayerTimesResponse) error {
+	return errors.New("no data")
+}
+
+// createTestYear builds one year of data. Hijri dates are taken from converter
+// and shifted by @hijriShift days to simulate moon sighting differences
+func createTestYear(year int, hijriShift int) models.PrayerTimesResponse {
+	response := models.PrayerTimesResponse{Sha1: "test-sha1"}
+
+	day := time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
+	for i := 1; day.Year() == year; i++ {
+		response.Year = append(response.Year, models.DailyPrayersDto{
+			ID:        i,
+			WeekID:    (i-1)/7 + 1,
+			Gregorian: day.Format("02/01/2006"),
+			Hijri:     HijriFromGregorian(day, hijriShift).String(),
+			Prayers: models.PrayerTimesDto{
+				Fajr:    "05:00 am",
+				Dhuhr:   "12:00 pm",
+				Asr:     "03:00 pm",
+				Maghrib: "06:00 pm",
+				Isha:    "07:30 pm",
+			},
+		})
+		day = day.AddDate(0, 0, 1)
+	}
+	return response
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		{date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), expected: "05/01/2026"},
+		{date: time.Date(2026, 9, 9, 0, 0, 0, 0, time.UTC), expected: "09/09/2026"},
+		{date: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), expected: "30/09/2026"},
+		{date: time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), expected: "10/10/2026"},
+		{date: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), expected: "31/12/2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly), func(t *testing.T) {
+			if result := formatDate(tt.date); result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatDateFindsEveryMonth(t *testing.T) {
+	data := createTestYear(2026, 0)
+	for month := time.January; month <= time.December; month++ {
+		date := time.Date(2026, month, 15, 0, 0, 0, 0, time.Local)
+		if getPrayerTimes(data, formatDate(date)) == nil {
+			t.Errorf("Expected day %v to be found in year data", date.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestFindHijriDate(t *testing.T) {
+	tests := []struct {
+		name         string
+		hijriShift   int
+		hijri        HijriDate
+		expectedDate time.Time
+	}{
+		{
+			name:         "Data matches converter",
+			hijri:        HijriDate{Year: 1447, Month: 9, Day: 27},
+			expectedDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:         "Data is one day behind converter",
+			hijriShift:   -1,
+			hijri:        HijriDate{Year: 1447, Month: 9, Day: 27},
+			expectedDate: time.Date(2026, 3, 17, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:         "Data is two days ahead of converter",
+			hijriShift:   2,
+			hijri:        HijriDate{Year: 1447, Month: 12, Day: 9},
+			expectedDate: time.Date(2026, 5, 24, 0, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := CreatePrayerTimesRepo(memoryStorageProvider(createTestYear(2026, tt.hijriShift)), nil, HijriOptions{})
+
+			result, err := repo.FindHijriDate(tt.hijri)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !SameDay(result, tt.expectedDate) {
+				t.Errorf("Expected %v but got %v", tt.expectedDate, result)
+			}
+		})
+	}
+}
+
+func TestGetEvents(t *testing.T) {
+	withEvents := createTestYear(2026, 0)
+	withEvents.Year[0].Event = models.Event{En: "Test Event", Ar: "حدث اختبار"}
+
+	tests := []struct {
+		name           string
+		data           models.PrayerTimesResponse
+		expectedEvents []DayEvent
+	}{
+		{
+			name: "Data events are used as is",
+			data: withEvents,
+			expectedEvents: []DayEvent{
+				{
+					Date:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+					Hijri: HijriDate{Year: 1447, Month: 7, Day: 12},
+					Event: Event{En: "Test Event", Ar: "حدث اختبار"},
+				},
+			},
+		},
+		{
+			name: "Built in events are used when data has none",
+			data: createTestYear(2026, 0),
+			expectedEvents: []DayEvent{
+				{Date: time.Date(2026, 1, 16, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 7, Day: 27}},
+				{Date: time.Date(2026, 2, 3, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 8, Day: 15}},
+				{Date: time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 9, Day: 1}},
+				{Date: time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 9, Day: 27}},
+				{Date: time.Date(2026, 3, 20, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 10, Day: 1}},
+				{Date: time.Date(2026, 5, 26, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 12, Day: 9}},
+				{Date: time.Date(2026, 5, 27, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1447, Month: 12, Day: 10}},
+				{Date: time.Date(2026, 6, 17, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1448, Month: 1, Day: 1}},
+				{Date: time.Date(2026, 6, 26, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1448, Month: 1, Day: 10}},
+				{Date: time.Date(2026, 8, 26, 0, 0, 0, 0, time.Local), Hijri: HijriDate{Year: 1448, Month: 3, Day: 12}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := CreatePrayerTimesRepo(memoryStorageProvider(tt.data), nil, HijriOptions{})
+
+			result, err := repo.GetEvents(2026)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(result) != len(tt.expectedEvents) {
+				t.Fatalf("Expected %v events but got %v", len(tt.expectedEvents), len(result))
+			}
+			for i, expected := range tt.expectedEvents {
+				if !SameDay(result[i].Date, expected.Date) {
+					t.Errorf("Expected date %v but got %v", expected.Date, result[i].Date)
+				}
+				if result[i].Hijri != expected.Hijri {
+					t.Errorf("Expected hijri %v but got %v", expected.Hijri, result[i].Hijri)
+				}
+				if result[i].Event.IsZero() {
+					t.Errorf("Expected event on %v but got none", expected.Date)
+				}
+				if !expected.Event.IsZero() && result[i].Event != expected.Event {
+					t.Errorf("Expected event %v but got %v", expected.Event, result[i].Event)
+				}
+			}
+		})
+	}
+}
+
+func TestGetWeekPrayerSchedules(t *testing.T) {
+	withoutWeekIDs := createTestYear(2026, 0)
+	for i := range withoutWeekIDs.Year {
+		withoutWeekIDs.Year[i].WeekID = 0
+	}
+	previousWithoutWeekIDs := createTestYear(2025, 0)
+	for i := range previousWithoutWeekIDs.Year {
+		previousWithoutWeekIDs.Year[i].WeekID = 0
+	}
+
+	tests := []struct {
+		name          string
+		data          []models.PrayerTimesResponse
+		date          time.Time
+		expectedFirst time.Time
+		expectedLen   int
+	}{
+		{
+			name:          "Grouped by data week id",
+			data:          []models.PrayerTimesResponse{createTestYear(2026, 0)},
+			date:          time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local),
+			expectedFirst: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+			expectedLen:   7,
+		},
+		{
+			name:          "Grouped by ISO week when data has no week id",
+			data:          []models.PrayerTimesResponse{withoutWeekIDs},
+			date:          time.Date(2026, 10, 21, 0, 0, 0, 0, time.Local),
+			expectedFirst: time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local),
+			expectedLen:   7,
+		},
+		{
+			name:          "ISO week crossing year start",
+			data:          []models.PrayerTimesResponse{previousWithoutWeekIDs, withoutWeekIDs},
+			date:          time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local),
+			expectedFirst: time.Date(2025, 12, 29, 0, 0, 0, 0, time.Local),
+			expectedLen:   7,
+		},
+		{
+			name:          "ISO week crossing year end",
+			data:          []models.PrayerTimesResponse{previousWithoutWeekIDs, withoutWeekIDs},
+			date:          time.Date(2025, 12, 30, 0, 0, 0, 0, time.Local),
+			expectedFirst: time.Date(2025, 12, 29, 0, 0, 0, 0, time.Local),
+			expectedLen:   7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := CreatePrayerTimesRepo(memoryStorageProvider(tt.data...), nil, HijriOptions{})
+
+			result, err := repo.GetWeekPrayerSchedules(tt.date)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(result) != tt.expectedLen {
+				t.Fatalf("Expected %v days but got %v", tt.expectedLen, len(result))
+			}
+			if !SameDay(result[0].Date, tt.expectedFirst) {
+				t.Errorf("Expected week to start at %v but got %v", tt.expectedFirst, result[0].Date)
+			}
+		})
+	}
+}
+
+func TestGetMonthPrayerSchedules(t *testing.T) {
+	tests := []struct {
+		name        string
+		month       time.Month
+		expectedLen int
+	}{
+		{name: "31 days month", month: time.March, expectedLen: 31},
+		{name: "30 days month", month: time.April, expectedLen: 30},
+		{name: "February", month: time.February, expectedLen: 28},
+		{name: "September", month: time.September, expectedLen: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := CreatePrayerTimesRepo(memoryStorageProvider(createTestYear(2026, 0)), nil, HijriOptions{})
+
+			result, err := repo.GetMonthPrayerSchedules(2026, tt.month)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(result) != tt.expectedLen {
+				t.Fatalf("Expected %v days but got %v", tt.expectedLen, len(result))
+			}
+			for i, s := range result {
+				if s.Date.Month() != tt.month || s.Date.Day() != i+1 {
+					t.Errorf("Expected day %v of %v but got %v", i+1, tt.month, s.Date)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSchedules(t *testing.T) {
+	repo := CreatePrayerTimesRepo(memoryStorageProvider(createTestYear(2025, 0), createTestYear(2026, 0)), nil, HijriOptions{})
+
+	t.Run("Range crossing year files", func(t *testing.T) {
+		from := time.Date(2025, 12, 30, 14, 0, 0, 0, time.Local)
+		to := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+
+		expected := from
+		count := 0
+		for schedule, err := range repo.GetSchedules(from, to) {
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !SameDay(schedule.Date, expected) {
+				t.Errorf("Expected %v but got %v", expected, schedule.Date)
+			}
+			expected = expected.AddDate(0, 0, 1)
+			count++
+		}
+		if count != 4 {
+			t.Errorf("Expected 4 days but got %v", count)
+		}
+	})
+
+	t.Run("Stops when consumer stops", func(t *testing.T) {
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+		to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)
+
+		count := 0
+		for range repo.GetSchedules(from, to) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("Expected 3 days but got %v", count)
+		}
+	})
+
+	t.Run("Empty range", func(t *testing.T) {
+		from := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+		to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+
+		for schedule := range repo.GetSchedules(from, to) {
+			t.Errorf("Expected no days but got %v", schedule.Date)
+		}
+	})
+}
+
+func TestActivePrayerTrackingAt(t *testing.T) {
+	tracking := ActivePrayerTracking{
+		PreviousPrayer:     "Dhuhr",
+		PreviousPrayerTime: time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC),
+		NextPrayer:         "Asr",
+		NextPrayerTime:     time.Date(2026, 3, 20, 15, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name              string
+		now               time.Time
+		expectedRemaining time.Duration
+		expectedProgress  float64
+	}{
+		{name: "At previous prayer", now: time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC), expectedRemaining: 3 * time.Hour, expectedProgress: 0},
+		{name: "Half way", now: time.Date(2026, 3, 20, 13, 30, 0, 0, time.UTC), expectedRemaining: 90 * time.Minute, expectedProgress: 50},
+		{name: "Seconds before next prayer", now: time.Date(2026, 3, 20, 14, 59, 30, 0, time.UTC), expectedRemaining: 30 * time.Second, expectedProgress: 100 - 30.0/(3*3600)*100},
+		{name: "After next prayer", now: time.Date(2026, 3, 20, 15, 0, 1, 0, time.UTC), expectedRemaining: 0, expectedProgress: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tracking.At(tt.now)
+			if result.TimeRemaining != tt.expectedRemaining {
+				t.Errorf("Expected %v remaining but got %v", tt.expectedRemaining, result.TimeRemaining)
+			}
+			if diff := result.Progress - tt.expectedProgress; diff > 0.001 || diff < -0.001 {
+				t.Errorf("Expected progress %v but got %v", tt.expectedProgress, result.Progress)
+			}
+			if result.NextPrayer != "Asr" || result.PreviousPrayer != "Dhuhr" {
+				t.Errorf("Expected prayers to be kept but got %v and %v", result.PreviousPrayer, result.NextPrayer)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// ErrNoSnapshot is returned when snapshot is missing or has no prayer left
+// after given time
+var ErrNoSnapshot = errors.New("no snapshot of upcoming prayers, run prayers once to refresh it")
+
+// GetSnapshotTracking returns tracking of prayer coming after @now, read from
+// @snapshotStorage only. Year data is never loaded and network is never used.
+// Previous prayer is empty before fajr of first snapshot day
+func GetSnapshotTracking(snapshotStorage storage.SnapshotStorage, now time.Time) (ActivePrayerTracking, error) {
+	var snapshot models.SnapshotDto
+	if err := snapshotStorage.LoadSnapshot(&snapshot); err != nil {
+		return ActivePrayerTracking{}, ErrNoSnapshot
+	}
+
+	days := []DailyPrayerSchedule{}
+	prayers := []Prayer{}
+	for _, dayDto := range snapshot.Days {
+		day, err := mapSnapshotDay(dayDto, now.Location())
+		if err != nil {
+			return ActivePrayerTracking{}, err
+		}
+		days = append(days, day)
+		prayers = append(prayers, day.Prayers...)
+	}
+
+	for i, p := range prayers {
+		if p.Time.Before(now) {
+			continue
+		}
+
+		tracking := ActivePrayerTracking{
+			NextPrayer:     p.Name,
+			NextPrayerTime: p.Time,
+			TimeRemaining:  p.Time.Sub(now),
+		}
+		for _, day := range days {
+			if SameDay(day.Date, now) {
+				tracking.DailyPrayerSchedule = day
+			}
+		}
+		if i > 0 {
+			tracking.PreviousPrayer = prayers[i-1].Name
+			tracking.PreviousPrayerTime = prayers[i-1].Time
+			total := p.Time.Sub(prayers[i-1].Time)
+			tracking.Progress = 100 - float64(tracking.TimeRemaining)/float64(total)*100
+		}
+		return tracking, nil
+	}
+	return ActivePrayerTracking{}, ErrNoSnapshot
+}
+
+// writeSnapshot saves today and tomorrow to snapshot storage, at most once per
+// repo. It is called whenever data of those days is loaded, so snapshot is
+// refreshed by any command
+func (r *PrayerTimesRepoImpl) writeSnapshot(now time.Time) {
+	if r.snapshotStorage == nil || r.snapshotWritten {
+		return
+	}
+	// set first, loading tomorrow may load another year and come back here
+	r.snapshotWritten = true
+
+	snapshot := models.SnapshotDto{CreatedAt: now.Format(time.RFC3339)}
+	for _, date := range []time.Time{now, now.AddDate(0, 0, 1)} {
+		dayPrayers := r.getDayPrayerTimeFor(date)
+		if dayPrayers == nil {
+			return
+		}
+		snapshot.Days = append(snapshot.Days, toSnapshotDay(*dayPrayers))
+	}
+
+	if err := r.snapshotStorage.SaveSnapshot(snapshot); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to save snapshot: %v\n", err)
+	}
+}
+
+func toSnapshotDay(dayPrayers DayPrayers) models.SnapshotDayDto {
+	day := models.SnapshotDayDto{
+		Date:    dayPrayers.Date.Format(time.DateOnly),
+		Event:   models.Event{En: dayPrayers.Event.En, Ar: dayPrayers.Event.Ar},
+		Prayers: []models.SnapshotPrayerDto{},
+	}
+	if !dayPrayers.Hijri.IsZero() {
+		day.Hijri = dayPrayers.Hijri.String()
+	}
+	for _, p := range dayPrayers.Prayers {
+		day.Prayers = append(day.Prayers, models.SnapshotPrayerDto{
+			Name: p.Name,
+			Time: p.Time.Format(time.RFC3339),
+		})
+	}
+	return day
+}
+
+func mapSnapshotDay(dayDto models.SnapshotDayDto, loc *time.Location) (DailyPrayerSchedule, error) {
+	date, err := time.ParseInLocation(time.DateOnly, dayDto.Date, loc)
+	if err != nil {
+		return DailyPrayerSchedule{}, fmt.Errorf("invalid snapshot date %q", dayDto.Date)
+	}
+
+	day := DailyPrayerSchedule{
+		Date:  date,
+		Event: Event{En: dayDto.Event.En, Ar: dayDto.Event.Ar},
+	}
+	if dayDto.Hijri != "" {
+		if day.Hijri, err = ParseHijriDate(dayDto.Hijri); err != nil {
+			return DailyPrayerSchedule{}, err
+		}
+	}
+	for _, p := range dayDto.Prayers {
+		prayerTime, err := time.Parse(time.RFC3339, p.Time)
+		if err != nil {
+			return DailyPrayerSchedule{}, fmt.Errorf("invalid snapshot time %q of %v", p.Time, p.Name)
+		}
+		day.Prayers = append(day.Prayers, Prayer{Name: p.Name, Time: prayerTime.In(loc)})
+	}
+	return day, nil
+}
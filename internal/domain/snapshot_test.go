@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// memorySnapshotStorage is an in memory @storage.SnapshotStorage, empty until saved
+type memorySnapshotStorage struct {
+	snapshot *models.SnapshotDto
+}
+
+func (s *memorySnapshotStorage) SaveSnapshot(snapshot models.SnapshotDto) error {
+	s.snapshot = &snapshot
+	return nil
+}
+
+func (s *memorySnapshotStorage) LoadSnapshot(snapshot *models.SnapshotDto) error {
+	if s.snapshot == nil {
+		return errors.New("no snapshot")
+	}
+	*snapshot = *s.snapshot
+	return nil
+}
+
+func TestRepoWritesSnapshot(t *testing.T) {
+	now := time.Now()
+	snapshotStorage := &memorySnapshotStorage{}
+	provider := memoryStorageProvider(createTestYear(now.Year(), 0), createTestYear(now.Year()+1, 0))
+	repo := CreatePrayerTimesRepo(provider, snapshotStorage, HijriOptions{})
+
+	if _, err := repo.GetDailyPrayerSchedule(now); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if snapshotStorage.snapshot == nil {
+		t.Fatalf("Expected snapshot to be written when year data is loaded")
+	}
+
+	days := snapshotStorage.snapshot.Days
+	if len(days) != 2 || days[0].Date != now.Format(time.DateOnly) || days[1].Date != now.AddDate(0, 0, 1).Format(time.DateOnly) {
+		t.Fatalf("Expected snapshot of today and tomorrow but got %+v", days)
+	}
+	if len(days[0].Prayers) != 5 || days[0].Hijri == "" {
+		t.Errorf("Expected 5 prayers and hijri date but got %+v", days[0])
+	}
+}
+
+func TestGetSnapshotTracking(t *testing.T) {
+	snapshotStorage := &memorySnapshotStorage{snapshot: &models.SnapshotDto{
+		Days: []models.SnapshotDayDto{
+			{
+				Date:  "2026-03-19",
+				Hijri: "1447-09-30",
+				Prayers: []models.SnapshotPrayerDto{
+					{Name: "Fajr", Time: "2026-03-19T05:00:00Z"},
+					{Name: "Dhuhr", Time: "2026-03-19T12:00:00Z"},
+					{Name: "Asr", Time: "2026-03-19T15:00:00Z"},
+					{Name: "Maghrib", Time: "2026-03-19T18:00:00Z"},
+					{Name: "Isha", Time: "2026-03-19T19:30:00Z"},
+				},
+			},
+			{
+				Date:  "2026-03-20",
+				Hijri: "1447-10-01",
+				Event: models.Event{En: "Eid al-Fitr"},
+				Prayers: []models.SnapshotPrayerDto{
+					{Name: "Fajr", Time: "2026-03-20T05:00:00Z"},
+					{Name: "Dhuhr", Time: "2026-03-20T12:00:00Z"},
+					{Name: "Asr", Time: "2026-03-20T15:00:00Z"},
+					{Name: "Maghrib", Time: "2026-03-20T18:00:00Z"},
+					{Name: "Isha", Time: "2026-03-20T19:30:00Z"},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name              string
+		now               time.Time
+		expectedNext      string
+		expectedPrevious  string
+		expectedRemaining time.Duration
+		expectedProgress  float64
+		expectedEvent     string
+		expectError       bool
+	}{
+		{name: "Before first fajr", now: time.Date(2026, 3, 19, 4, 0, 0, 0, time.UTC), expectedNext: "Fajr", expectedRemaining: time.Hour},
+		{name: "Between dhuhr and asr", now: time.Date(2026, 3, 19, 13, 30, 0, 0, time.UTC), expectedNext: "Asr", expectedPrevious: "Dhuhr", expectedRemaining: 90 * time.Minute, expectedProgress: 50},
+		{name: "After isha", now: time.Date(2026, 3, 19, 23, 0, 0, 0, time.UTC), expectedNext: "Fajr", expectedPrevious: "Isha", expectedRemaining: 6 * time.Hour, expectedProgress: 3.5 / 9.5 * 100},
+		{name: "Snapshot of yesterday", now: time.Date(2026, 3, 20, 14, 0, 0, 0, time.UTC), expectedNext: "Asr", expectedPrevious: "Dhuhr", expectedRemaining: time.Hour, expectedProgress: 200.0 / 3, expectedEvent: "Eid al-Fitr"},
+		{name: "Out of date snapshot", now: time.Date(2026, 3, 20, 20, 0, 0, 0, time.UTC), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := GetSnapshotTracking(snapshotStorage, tt.now)
+			if tt.expectError {
+				if !errors.Is(err, ErrNoSnapshot) {
+					t.Errorf("Expected ErrNoSnapshot but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			if result.NextPrayer != tt.expectedNext || result.PreviousPrayer != tt.expectedPrevious {
+				t.Errorf("Expected %v after %v but got %v after %v", tt.expectedNext, tt.expectedPrevious, result.NextPrayer, result.PreviousPrayer)
+			}
+			if result.TimeRemaining != tt.expectedRemaining {
+				t.Errorf("Expected %v remaining but got %v", tt.expectedRemaining, result.TimeRemaining)
+			}
+			if diff := result.Progress - tt.expectedProgress; diff > 0.01 || diff < -0.01 {
+				t.Errorf("Expected progress %v but got %v", tt.expectedProgress, result.Progress)
+			}
+			if !SameDay(result.Date, tt.now) || result.Event.En != tt.expectedEvent {
+				t.Errorf("Expected schedule of %v with event %q but got %v with %q", tt.now, tt.expectedEvent, result.Date, result.Event.En)
+			}
+		})
+	}
+}
+
+func TestGetSnapshotTrackingWithoutSnapshot(t *testing.T) {
+	_, err := GetSnapshotTracking(&memorySnapshotStorage{}, time.Now())
+	if !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot but got %v", err)
+	}
+}
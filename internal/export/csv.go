@@ -0,0 +1,108 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+var (
+	// CSVColumns are all columns csv export supports
+	CSVColumns = append([]string{"date", "weekday", "hijri", "sunrise", "event"}, prayerColumns()...)
+
+	DefaultCSVColumns = []string{"date", "hijri", "fajr", "sunrise", "dhuhr", "asr", "maghrib", "isha", "event"}
+)
+
+type CSVOptions struct {
+	Columns    []string
+	TimeLayout string
+	Delimiter  rune
+	Language   domain.HijriLanguage
+}
+
+// ValidateCSVColumns returns error on first column not in @CSVColumns
+func ValidateCSVColumns(columns []string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("no csv columns, expected some of %v", strings.Join(CSVColumns, ","))
+	}
+	for _, c := range columns {
+		if !slices.Contains(CSVColumns, c) {
+			return fmt.Errorf("invalid csv column %q, expected one of %v", c, strings.Join(CSVColumns, ","))
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes a header row of @options columns then a row per schedule.
+// Writing stops at first schedules error
+func WriteCSV(w io.Writer, schedules iter.Seq2[domain.DailyPrayerSchedule, error], options CSVOptions) error {
+	if err := ValidateCSVColumns(options.Columns); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if options.Delimiter != 0 {
+		writer.Comma = options.Delimiter
+	}
+
+	if err := writer.Write(options.Columns); err != nil {
+		return err
+	}
+	for schedule, err := range schedules {
+		if err != nil {
+			return err
+		}
+
+		row := []string{}
+		for _, c := range options.Columns {
+			row = append(row, csvCell(schedule, c, options))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvCell(schedule domain.DailyPrayerSchedule, column string, options CSVOptions) string {
+	switch column {
+	case "date":
+		return schedule.Date.Format(time.DateOnly)
+	case "weekday":
+		return schedule.Date.Weekday().String()
+	case "hijri":
+		return schedule.Hijri.Format(options.Language)
+	case "sunrise":
+		if schedule.Sunrise.IsZero() {
+			return ""
+		}
+		return schedule.Sunrise.Format(options.TimeLayout)
+	case "event":
+		return schedule.Event.Name(options.Language)
+	}
+
+	for _, p := range schedule.Prayers {
+		if strings.EqualFold(p.Name, column) {
+			return p.Time.Format(options.TimeLayout)
+		}
+	}
+	return ""
+}
+
+// prayerColumns returns prayer names as lower case column names
+func prayerColumns() []string {
+	columns := []string{}
+	for _, name := range models.SortedPrayerNames {
+		columns = append(columns, strings.ToLower(name))
+	}
+	return columns
+}
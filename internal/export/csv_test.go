@@ -0,0 +1,108 @@
+package export
+
+import (
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// testSchedules yields @schedules then @err if not nil
+func testSchedules(schedules []domain.DailyPrayerSchedule, err error) iter.Seq2[domain.DailyPrayerSchedule, error] {
+	return func(yield func(domain.DailyPrayerSchedule, error) bool) {
+		for _, s := range schedules {
+			if !yield(s, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(domain.DailyPrayerSchedule{}, err)
+		}
+	}
+}
+
+func testSchedule(year int, month time.Month, day int) domain.DailyPrayerSchedule {
+	at := func(hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	date := at(0, 0)
+	return domain.DailyPrayerSchedule{
+		Date:  date,
+		Hijri: domain.HijriFromGregorian(date, 0),
+		Prayers: []domain.Prayer{
+			{Name: "Fajr", Time: at(5, 1)},
+			{Name: "Dhuhr", Time: at(12, 1)},
+			{Name: "Asr", Time: at(15, 29)},
+			{Name: "Maghrib", Time: at(17, 38)},
+			{Name: "Isha", Time: at(19, 3)},
+		},
+		Sunrise: at(6, 16),
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	eid := testSchedule(2026, 3, 20)
+	eid.Event = domain.Event{En: "Eid al-Fitr", Ar: "عيد الفطر"}
+	withoutSunrise := testSchedule(2026, 3, 21)
+	withoutSunrise.Sunrise = time.Time{}
+
+	tests := []struct {
+		name        string
+		schedules   iter.Seq2[domain.DailyPrayerSchedule, error]
+		options     CSVOptions
+		expected    string
+		expectError bool
+	}{
+		{
+			name:      "Default columns",
+			schedules: testSchedules([]domain.DailyPrayerSchedule{eid, withoutSunrise}, nil),
+			options:   CSVOptions{Columns: DefaultCSVColumns, TimeLayout: "3:04 pm"},
+			expected: "date,hijri,fajr,sunrise,dhuhr,asr,maghrib,isha,event\n" +
+				"2026-03-20,1 Shawwal 1447 AH,5:01 am,6:16 am,12:01 pm,3:29 pm,5:38 pm,7:03 pm,Eid al-Fitr\n" +
+				"2026-03-21,2 Shawwal 1447 AH,5:01 am,,12:01 pm,3:29 pm,5:38 pm,7:03 pm,\n",
+		},
+		{
+			name:      "Custom columns, layout, delimiter and language",
+			schedules: testSchedules([]domain.DailyPrayerSchedule{eid}, nil),
+			options: CSVOptions{
+				Columns:    []string{"weekday", "isha", "event"},
+				TimeLayout: "15:04",
+				Delimiter:  '\t',
+				Language:   domain.HijriLanguageAr,
+			},
+			expected: "weekday\tisha\tevent\nFriday\t19:03\tعيد الفطر\n",
+		},
+		{
+			name:        "Invalid column",
+			schedules:   testSchedules(nil, nil),
+			options:     CSVOptions{Columns: []string{"date", "shuruq"}},
+			expectError: true,
+		},
+		{
+			name:        "Schedules error",
+			schedules:   testSchedules([]domain.DailyPrayerSchedule{eid}, errors.New("no data")),
+			options:     CSVOptions{Columns: DefaultCSVColumns, TimeLayout: "3:04 pm"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := WriteCSV(&sb, tt.schedules, tt.options)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !tt.expectError && sb.String() != tt.expected {
+				t.Errorf("Expected:\n%v\nGot:\n%v", tt.expected, sb.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"html/template"
+	"io"
+	"iter"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+var htmlTemplate = template.Must(template.New("timetable").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}" dir="{{.Dir}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, -apple-system, "Segoe UI", Tahoma, sans-serif; color: #222; margin: 2em auto; max-width: 60em; padding: 0 1em; }
+h1 { text-align: center; margin-bottom: 0.5em; }
+h2 { margin-bottom: 0.1em; }
+.hijri { color: #5c6bc0; margin-top: 0; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; font-variant-numeric: tabular-nums; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.5em; text-align: center; white-space: nowrap; }
+th { background: #37474f; color: #fff; }
+td.event { white-space: normal; }
+tr.friday { background: #e8f5e9; font-weight: bold; }
+tr.has-event { background: #fff3e0; }
+tr.has-event td.event { color: #e65100; font-weight: bold; }
+@media print {
+  body { margin: 0; max-width: none; }
+  section { break-after: page; }
+  section:last-child { break-after: auto; }
+  tr { break-inside: avoid; }
+  th, tr.friday, tr.has-event { -webkit-print-color-adjust: exact; print-color-adjust: exact; }
+}
+</style>
+</head>
+<body>
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+{{- range .Months}}
+<section>
+<h2>{{.Title}}</h2>
+{{- if .HijriTitle}}
+<p class="hijri">{{.HijriTitle}}</p>
+{{- end}}
+<table>
+<thead>
+<tr>{{range $.Headers}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if or .Friday .Event}} class="{{if .Friday}}friday{{end}}{{if and .Friday .Event}} {{end}}{{if .Event}}has-event{{end}}"{{end}}><td>{{.Date}}</td><td>{{.Hijri}}</td>{{range .Times}}<td>{{.}}</td>{{end}}<td class="event">{{.Event}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes a self-contained html page with a timetable per month,
+// fridays and events highlighted. It prints on a page per month
+func WriteHTML(w io.Writer, schedules iter.Seq2[domain.DailyPrayerSchedule, error], options TimetableOptions) error {
+	months, err := collectTimetable(schedules, options)
+	if err != nil {
+		return err
+	}
+
+	lang := "en"
+	if options.Language == domain.HijriLanguageAr {
+		lang = "ar"
+	}
+	return htmlTemplate.Execute(w, map[string]any{
+		"Lang":    lang,
+		"Dir":     timetableDirection(options.Language),
+		"Title":   options.Title,
+		"Headers": timetableHeaders(options.Language),
+		"Months":  months,
+	})
+}
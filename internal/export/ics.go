@@ -0,0 +1,156 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+var nonSlugCharsRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+type ICSOptions struct {
+	// Name of the calendar shown by calendar apps
+	Name string
+
+	// Duration each prayer event blocks
+	Duration time.Duration
+
+	// Alarms are reminders, each one is how long before prayer it fires
+	Alarms []time.Duration
+
+	// Location is shown on events, and is part of their UIDs so calendars of
+	// different locations do not override each other
+	Location string
+
+	// Prayers to add events for, by name case insensitive. Empty means all
+	Prayers []string
+
+	// Stamp is when calendar was generated
+	Stamp time.Time
+}
+
+// WriteICS writes an RFC 5545 calendar with an event per prayer. Event UIDs
+// depend only on day, prayer and location, so importing calendar again updates
+// events instead of duplicating them. Writing stops at first schedules error
+func WriteICS(w io.Writer, schedules iter.Seq2[domain.DailyPrayerSchedule, error], options ICSOptions) error {
+	ics := &icsWriter{w: w}
+
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//mabd-dev//prayer-times-cli//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+	if options.Name != "" {
+		ics.line("X-WR-CALNAME:" + escapeICSText(options.Name))
+	}
+
+	for schedule, err := range schedules {
+		if err != nil {
+			return err
+		}
+		for _, p := range schedule.Prayers {
+			if includesPrayer(options.Prayers, p.Name) {
+				writeICSEvent(ics, schedule, p, options)
+			}
+		}
+	}
+
+	ics.line("END:VCALENDAR")
+	return ics.err
+}
+
+func writeICSEvent(ics *icsWriter, schedule domain.DailyPrayerSchedule, prayer domain.Prayer, options ICSOptions) {
+	description := schedule.Hijri.Format(domain.HijriLanguageEn)
+	if !schedule.Event.IsZero() {
+		description = fmt.Sprintf("%v\n%v", description, schedule.Event.Name(domain.HijriLanguageEn))
+	}
+
+	ics.line("BEGIN:VEVENT")
+	ics.line("UID:" + icsUID(prayer, options.Location))
+	ics.line("DTSTAMP:" + options.Stamp.UTC().Format(icsTimeLayout))
+	ics.line("DTSTART:" + prayer.Time.UTC().Format(icsTimeLayout))
+	ics.line("DTEND:" + prayer.Time.Add(options.Duration).UTC().Format(icsTimeLayout))
+	ics.line("SUMMARY:" + escapeICSText(prayer.Name))
+	if description != "" {
+		ics.line("DESCRIPTION:" + escapeICSText(description))
+	}
+	if options.Location != "" {
+		ics.line("LOCATION:" + escapeICSText(options.Location))
+	}
+	ics.line("TRANSP:OPAQUE")
+	for _, alarm := range options.Alarms {
+		ics.line("BEGIN:VALARM")
+		ics.line("ACTION:DISPLAY")
+		ics.line("DESCRIPTION:" + escapeICSText(fmt.Sprintf("%v in %v minutes", prayer.Name, int(alarm.Minutes()))))
+		ics.line(fmt.Sprintf("TRIGGER:-PT%vM", int(alarm.Minutes())))
+		ics.line("END:VALARM")
+	}
+	ics.line("END:VEVENT")
+}
+
+func includesPrayer(prayers []string, name string) bool {
+	if len(prayers) == 0 {
+		return true
+	}
+	for _, p := range prayers {
+		if strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// icsUID returns id like "20260320-fajr-beirut@prayer-times-cli"
+func icsUID(prayer domain.Prayer, location string) string {
+	uid := fmt.Sprintf("%v-%v", prayer.Time.Format("20060102"), strings.ToLower(prayer.Name))
+	if slug := strings.Trim(nonSlugCharsRegex.ReplaceAllString(strings.ToLower(location), "-"), "-"); slug != "" {
+		uid = fmt.Sprintf("%v-%v", uid, slug)
+	}
+	return uid + "@prayer-times-cli"
+}
+
+// escapeICSText escapes TEXT values as RFC 5545 section 3.3.11 says
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// icsWriter writes CRLF terminated lines folded at 75 octets, keeping first
+// error so callers check it once at the end
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ics *icsWriter) line(s string) {
+	if ics.err != nil {
+		return
+	}
+
+	var sb strings.Builder
+	lineLength := 0
+	for _, r := range s {
+		runeLength := len(string(r))
+		if lineLength+runeLength > 75 {
+			// continuation lines start with a space, which counts to their length
+			sb.WriteString("\r\n ")
+			lineLength = 1
+		}
+		sb.WriteRune(r)
+		lineLength += runeLength
+	}
+	sb.WriteString("\r\n")
+
+	_, ics.err = io.WriteString(ics.w, sb.String())
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// assertGolden compares @actual to testdata/@name, or overwrites it with -update
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if actual != string(expected) {
+		t.Errorf("Output does not match %v, run tests with -update if change is expected\nExpected:\n%v\nGot:\n%v", path, string(expected), actual)
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	eid := testSchedule(2026, 3, 20)
+	eid.Event = domain.Event{En: "Eid al-Fitr", Ar: "عيد الفطر"}
+	stamp := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		golden    string
+		schedules []domain.DailyPrayerSchedule
+		options   ICSOptions
+	}{
+		{
+			name:      "Without alarms and location",
+			golden:    "prayers.ics",
+			schedules: []domain.DailyPrayerSchedule{testSchedule(2026, 3, 19)},
+			options: ICSOptions{
+				Duration: 20 * time.Minute,
+				Stamp:    stamp,
+			},
+		},
+		{
+			name:      "With alarms, location and event",
+			golden:    "prayers-alarms.ics",
+			schedules: []domain.DailyPrayerSchedule{eid},
+			options: ICSOptions{
+				Name:     "Prayer times, Beirut",
+				Duration: 15 * time.Minute,
+				Alarms:   []time.Duration{10 * time.Minute, 2 * time.Minute},
+				Location: "Mosque; Main St., Beirut - a long location name that needs folding",
+				Stamp:    stamp,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := WriteICS(&sb, testSchedules(tt.schedules, nil), tt.options)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			assertGolden(t, tt.golden, sb.String())
+
+			for _, line := range strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("Expected lines of at most 75 octets, got %v: %q", len(line), line)
+				}
+			}
+		})
+	}
+}
+
+func TestICSUIDIsStable(t *testing.T) {
+	schedule := testSchedule(2026, 3, 19)
+	stamp := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var first, second strings.Builder
+	WriteICS(&first, testSchedules([]domain.DailyPrayerSchedule{schedule}, nil), ICSOptions{Location: "Beirut", Stamp: stamp})
+	WriteICS(&second, testSchedules([]domain.DailyPrayerSchedule{schedule}, nil), ICSOptions{Location: "Beirut", Stamp: stamp.Add(24 * time.Hour), Duration: time.Hour})
+
+	uids := func(ics string) []string {
+		result := []string{}
+		for _, line := range strings.Split(ics, "\r\n") {
+			if strings.HasPrefix(line, "UID:") {
+				result = append(result, line)
+			}
+		}
+		return result
+	}
+
+	firstUIDs, secondUIDs := uids(first.String()), uids(second.String())
+	if len(firstUIDs) != 5 {
+		t.Fatalf("Expected 5 events but got %v", len(firstUIDs))
+	}
+	for i := range firstUIDs {
+		if firstUIDs[i] != secondUIDs[i] {
+			t.Errorf("Expected same UID on regeneration, got %v and %v", firstUIDs[i], secondUIDs[i])
+		}
+	}
+	if firstUIDs[0] != "UID:20260319-fajr-beirut@prayer-times-cli" {
+		t.Errorf("Unexpected UID %v", firstUIDs[0])
+	}
+}
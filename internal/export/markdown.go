@@ -0,0 +1,63 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// WriteMarkdown writes a markdown table per month, fridays and events in bold.
+// Arabic timetables are wrapped in a right to left div, which renderers that
+// allow html, like most wikis, respect
+func WriteMarkdown(w io.Writer, schedules iter.Seq2[domain.DailyPrayerSchedule, error], options TimetableOptions) error {
+	months, err := collectTimetable(schedules, options)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	rtl := timetableDirection(options.Language) == "rtl"
+	if rtl {
+		sb.WriteString("<div dir=\"rtl\">\n\n")
+	}
+	if options.Title != "" {
+		fmt.Fprintf(&sb, "# %v\n\n", options.Title)
+	}
+
+	headers := timetableHeaders(options.Language)
+	for _, month := range months {
+		fmt.Fprintf(&sb, "## %v\n\n", month.Title)
+		if month.HijriTitle != "" {
+			fmt.Fprintf(&sb, "%v\n\n", month.HijriTitle)
+		}
+
+		writeMarkdownRow(&sb, headers)
+		writeMarkdownRow(&sb, strings.Split(strings.Repeat("---,", len(headers)-1)+"---", ","))
+		for _, row := range month.Rows {
+			date := escapeMarkdownCell(row.Date)
+			if row.Friday {
+				date = "**" + date + "**"
+			}
+			event := escapeMarkdownCell(row.Event)
+			if event != "" {
+				event = "**" + event + "**"
+			}
+			cells := append([]string{date, escapeMarkdownCell(row.Hijri)}, row.Times...)
+			writeMarkdownRow(&sb, append(cells, event))
+		}
+		sb.WriteString("\n")
+	}
+
+	if rtl {
+		sb.WriteString("</div>\n")
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	fmt.Fprintf(sb, "| %v |\n", strings.Join(cells, " | "))
+}
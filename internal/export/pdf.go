@@ -0,0 +1,192 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+const (
+	pdfMargin       = 10.0
+	pdfFooterHeight = 8.0
+	pdfMaxRowHeight = 7.0
+)
+
+// pdfColumnWidths are fractions of page width, in @timetableHeaders order
+var pdfColumnWidths = []float64{0.11, 0.14, 0.08, 0.08, 0.08, 0.08, 0.08, 0.08, 0.27}
+
+type PDFPageSize string
+
+const (
+	PDFPageA4     PDFPageSize = "a4"
+	PDFPageLetter PDFPageSize = "letter"
+)
+
+// ParsePDFPageSize returns error if @s is not one of supported page sizes
+func ParsePDFPageSize(s string) (PDFPageSize, error) {
+	switch PDFPageSize(strings.ToLower(s)) {
+	case PDFPageA4:
+		return PDFPageA4, nil
+	case PDFPageLetter:
+		return PDFPageLetter, nil
+	}
+	return "", fmt.Errorf("invalid page size %q, expected a4 or letter", s)
+}
+
+type PDFOptions struct {
+	// TimetableOptions title is printed as header of each page, e.g. name of
+	// masjid. Only english is supported, since core pdf fonts have no arabic
+	TimetableOptions
+
+	// Location is printed under title
+	Location string
+
+	PageSize  PDFPageSize
+	Landscape bool
+
+	// Source of data, printed in footer of each page
+	Source string
+
+	// Stamp is when document was generated
+	Stamp time.Time
+}
+
+// WritePDF writes a print ready pdf with a page per month, fridays and events
+// highlighted. Nothing is written if schedules fail
+func WritePDF(w io.Writer, schedules iter.Seq2[domain.DailyPrayerSchedule, error], options PDFOptions) error {
+	if options.Language == domain.HijriLanguageAr {
+		return errors.New("pdf export supports english only, core pdf fonts have no arabic glyphs")
+	}
+	months, err := collectTimetable(schedules, options.TimetableOptions)
+	if err != nil {
+		return err
+	}
+
+	orientation := "P"
+	if options.Landscape {
+		orientation = "L"
+	}
+	pageSize := "A4"
+	if options.PageSize == PDFPageLetter {
+		pageSize = "Letter"
+	}
+
+	pdf := fpdf.New(orientation, "mm", pageSize, "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	// sorted catalog and fixed dates make same input give same bytes
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(options.Stamp)
+	pdf.SetModificationDate(options.Stamp)
+	pdf.SetTitle(options.Title, true)
+	pdf.SetCreator("prayer-times-cli", false)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		_, pageHeight := pdf.GetPageSize()
+		pdf.SetY(pageHeight - pdfMargin - pdfFooterHeight/2)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		if options.Source != "" {
+			pdf.CellFormat(0, pdfFooterHeight/2, tr("Prayer times data: "+options.Source), "", 0, "L", false, 0, "")
+		}
+		pdf.CellFormat(0, pdfFooterHeight/2, fmt.Sprintf("Page %v/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	headers := timetableHeaders(options.Language)
+	for _, month := range months {
+		pdf.AddPage()
+		writePDFHeader(pdf, tr, month, options)
+		writePDFTable(pdf, tr, headers, month.Rows)
+	}
+
+	return pdf.Output(w)
+}
+
+func writePDFHeader(pdf *fpdf.Fpdf, tr func(string) string, month timetableMonth, options PDFOptions) {
+	pdf.SetTextColor(34, 34, 34)
+	if options.Title != "" {
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.CellFormat(0, 8, tr(options.Title), "", 1, "C", false, 0, "")
+	}
+	if options.Location != "" {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, 6, tr(options.Location), "", 1, "C", false, 0, "")
+	}
+
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 7, tr(month.Title), "", 1, "L", false, 0, "")
+	if month.HijriTitle != "" {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.SetTextColor(92, 107, 192)
+		pdf.CellFormat(0, 5, tr(month.HijriTitle), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+}
+
+// writePDFTable fits @rows and header row in space left above footer
+func writePDFTable(pdf *fpdf.Fpdf, tr func(string) string, headers []string, rows []timetableRow) {
+	pageWidth, pageHeight := pdf.GetPageSize()
+	tableWidth := pageWidth - 2*pdfMargin
+	available := pageHeight - pdfMargin - pdfFooterHeight - pdf.GetY()
+	rowHeight := math.Min(pdfMaxRowHeight, available/float64(len(rows)+1))
+	// font is about 60% of row height, in points
+	fontSize := math.Min(9, rowHeight*0.6/0.3528)
+
+	pdf.SetDrawColor(204, 204, 204)
+	pdf.SetFillColor(55, 71, 79)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", fontSize)
+	for i, header := range headers {
+		pdf.CellFormat(tableWidth*pdfColumnWidths[i], rowHeight, tr(header), "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	for _, row := range rows {
+		style := ""
+		fill := row.Friday || row.Event != ""
+		switch {
+		case row.Event != "":
+			pdf.SetFillColor(255, 243, 224)
+		case row.Friday:
+			pdf.SetFillColor(232, 245, 233)
+		}
+		if row.Friday {
+			style = "B"
+		}
+
+		pdf.SetFont("Helvetica", style, fontSize)
+		pdf.SetTextColor(34, 34, 34)
+		cells := append([]string{row.Date, row.Hijri}, row.Times...)
+		for i, cell := range cells {
+			pdf.CellFormat(tableWidth*pdfColumnWidths[i], rowHeight, tr(cell), "1", 0, "C", fill, 0, "")
+		}
+
+		eventWidth := tableWidth * pdfColumnWidths[len(cells)]
+		pdf.SetFont("Helvetica", "B", fontSize)
+		pdf.SetTextColor(230, 81, 0)
+		pdf.CellFormat(eventWidth, rowHeight, fitPDFText(pdf, tr(row.Event), eventWidth-2), "1", 0, "L", fill, 0, "")
+		pdf.Ln(-1)
+	}
+}
+
+// fitPDFText cuts @s with an ellipsis until it fits in @width with current
+// font. @s is already translated to a single byte code page
+func fitPDFText(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for len(s) > 0 && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+func TestWritePDF(t *testing.T) {
+	eid := testSchedule(2026, 3, 20)
+	eid.Event = domain.Event{En: "Eid al-Fitr, a very long event name that does not fit in its column", Ar: "عيد الفطر"}
+	schedules := []domain.DailyPrayerSchedule{testSchedule(2026, 3, 19), eid, testSchedule(2026, 4, 1)}
+	stamp := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		options          PDFOptions
+		expectedMediaBox string
+	}{
+		{
+			name:             "A4 portrait",
+			options:          PDFOptions{PageSize: PDFPageA4, Source: "example.org", Stamp: stamp},
+			expectedMediaBox: "/MediaBox [0 0 595.28 841.89]",
+		},
+		{
+			name: "Letter landscape with header",
+			options: PDFOptions{
+				TimetableOptions: TimetableOptions{Title: "Masjid Al-Noor", TimeLayout: "15:04"},
+				Location:         "Beirut",
+				PageSize:         PDFPageLetter,
+				Landscape:        true,
+				Stamp:            stamp,
+			},
+			expectedMediaBox: "/MediaBox [0 0 792.00 612.00]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second bytes.Buffer
+			if err := WritePDF(&first, testSchedules(schedules, nil), tt.options); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			WritePDF(&second, testSchedules(schedules, nil), tt.options)
+
+			pdf := first.String()
+			if !strings.HasPrefix(pdf, "%PDF-") {
+				t.Fatalf("Expected pdf header but got %q", pdf[:min(len(pdf), 10)])
+			}
+			if pages := strings.Count(pdf, "/Type /Page\n"); pages != 2 {
+				t.Errorf("Expected a page per month, 2, but got %v", pages)
+			}
+			if !strings.Contains(pdf, tt.expectedMediaBox) {
+				t.Errorf("Expected %v in pdf", tt.expectedMediaBox)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Errorf("Expected same output for same input and stamp")
+			}
+		})
+	}
+}
+
+func TestWritePDFErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []domain.DailyPrayerSchedule
+		err       error
+		options   PDFOptions
+	}{
+		{
+			name:      "Schedules error",
+			schedules: []domain.DailyPrayerSchedule{testSchedule(2026, 3, 19)},
+			err:       errors.New("missing day"),
+		},
+		{
+			name:      "Arabic",
+			schedules: []domain.DailyPrayerSchedule{testSchedule(2026, 3, 19)},
+			options:   PDFOptions{TimetableOptions: TimetableOptions{Language: domain.HijriLanguageAr}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WritePDF(&buf, testSchedules(tt.schedules, tt.err), tt.options); err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Expected nothing written on error, got %v bytes", buf.Len())
+			}
+		})
+	}
+}
+
+func TestParsePDFPageSize(t *testing.T) {
+	for input, expected := range map[string]PDFPageSize{"a4": PDFPageA4, "A4": PDFPageA4, "Letter": PDFPageLetter} {
+		result, err := ParsePDFPageSize(input)
+		if err != nil || result != expected {
+			t.Errorf("Expected %v for %q but got %v, %v", expected, input, result, err)
+		}
+	}
+	if _, err := ParsePDFPageSize("a3"); err == nil {
+		t.Errorf("Expected error for a3 but got none")
+	}
+}
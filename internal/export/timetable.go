@@ -0,0 +1,144 @@
+package export
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+var (
+	arabicMonthNames = []string{
+		"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو",
+		"يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر",
+	}
+
+	arabicWeekdayNames = []string{
+		"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت",
+	}
+)
+
+// TimetableOptions are options of html and markdown timetables
+type TimetableOptions struct {
+	// Title shown above all months, e.g. name of masjid
+	Title string
+
+	// TimeLayout of prayer times, e.g. 15:04
+	TimeLayout string
+
+	// Language of headers, names and hijri dates. Arabic timetables are right to left
+	Language domain.HijriLanguage
+}
+
+// timetableMonth is a table of a gregorian month, ready to be written
+type timetableMonth struct {
+	Title      string
+	HijriTitle string
+	Rows       []timetableRow
+}
+
+type timetableRow struct {
+	Date   string
+	Hijri  string
+	Times  []string
+	Event  string
+	Friday bool
+}
+
+// collectTimetable groups @schedules by gregorian month. Collecting stops at
+// first schedules error
+func collectTimetable(schedules iter.Seq2[domain.DailyPrayerSchedule, error], options TimetableOptions) ([]timetableMonth, error) {
+	months := []timetableMonth{}
+	var first, last domain.HijriDate
+
+	for schedule, err := range schedules {
+		if err != nil {
+			return nil, err
+		}
+
+		title := monthTitle(schedule.Date, options.Language)
+		if len(months) == 0 || months[len(months)-1].Title != title {
+			months = append(months, timetableMonth{Title: title})
+			first = schedule.Hijri
+		}
+		last = schedule.Hijri
+
+		month := &months[len(months)-1]
+		month.Rows = append(month.Rows, toTimetableRow(schedule, options))
+		if first == last {
+			month.HijriTitle = first.Format(options.Language)
+		} else if !first.IsZero() && !last.IsZero() {
+			month.HijriTitle = fmt.Sprintf("%v – %v", first.Format(options.Language), last.Format(options.Language))
+		}
+	}
+	return months, nil
+}
+
+func toTimetableRow(schedule domain.DailyPrayerSchedule, options TimetableOptions) timetableRow {
+	row := timetableRow{
+		Date:   fmt.Sprintf("%v %v", weekdayName(schedule.Date.Weekday(), options.Language), schedule.Date.Format("02/01")),
+		Event:  schedule.Event.Name(options.Language),
+		Friday: schedule.Date.Weekday() == time.Friday,
+	}
+	if !schedule.Hijri.IsZero() {
+		row.Hijri = fmt.Sprintf("%v %v", schedule.Hijri.Day, schedule.Hijri.MonthName(options.Language))
+	}
+
+	sunrise := ""
+	if !schedule.Sunrise.IsZero() {
+		sunrise = schedule.Sunrise.Format(options.TimeLayout)
+	}
+	for _, p := range schedule.Prayers {
+		row.Times = append(row.Times, p.Time.Format(options.TimeLayout))
+		// sunrise goes between fajr and dhuhr
+		if p.Name == models.SortedPrayerNames[0] {
+			row.Times = append(row.Times, sunrise)
+		}
+	}
+	return row
+}
+
+// timetableHeaders returns column headers in @lang
+func timetableHeaders(lang domain.HijriLanguage) []string {
+	names := append([]string{models.SortedPrayerNames[0], "Sunrise"}, models.SortedPrayerNames[1:]...)
+	if lang != domain.HijriLanguageAr {
+		return append(append([]string{"Date", "Hijri"}, names...), "Event")
+	}
+
+	headers := []string{"التاريخ", "الهجري"}
+	for _, name := range names {
+		headers = append(headers, models.ArabicPrayerNames[name])
+	}
+	return append(headers, "المناسبة")
+}
+
+// monthTitle returns title like "March 2026"
+func monthTitle(date time.Time, lang domain.HijriLanguage) string {
+	if lang == domain.HijriLanguageAr {
+		return fmt.Sprintf("%v %v", arabicMonthNames[date.Month()-1], date.Year())
+	}
+	return date.Format("January 2006")
+}
+
+func weekdayName(weekday time.Weekday, lang domain.HijriLanguage) string {
+	if lang == domain.HijriLanguageAr {
+		return arabicWeekdayNames[weekday]
+	}
+	return weekday.String()[:3]
+}
+
+// timetableDirection returns text direction of @lang, rtl or ltr
+func timetableDirection(lang domain.HijriLanguage) string {
+	if lang == domain.HijriLanguageAr {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// escapeMarkdownCell escapes characters that break markdown table cells
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
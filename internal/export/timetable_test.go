@@ -0,0 +1,113 @@
+package export
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+func TestWriteTimetable(t *testing.T) {
+	eid := testSchedule(2026, 3, 20)
+	eid.Event = domain.Event{En: "Eid al-Fitr | Shawwal", Ar: "عيد الفطر"}
+	schedules := []domain.DailyPrayerSchedule{
+		testSchedule(2026, 3, 19),
+		eid,
+		testSchedule(2026, 4, 1),
+	}
+
+	tests := []struct {
+		name    string
+		golden  string
+		write   func(sb *strings.Builder, options TimetableOptions) error
+		options TimetableOptions
+	}{
+		{
+			name:   "html",
+			golden: "timetable.html",
+			write: func(sb *strings.Builder, options TimetableOptions) error {
+				return WriteHTML(sb, testSchedules(schedules, nil), options)
+			},
+			options: TimetableOptions{Title: "Prayer times <Beirut>", TimeLayout: "3:04 pm"},
+		},
+		{
+			name:   "Arabic html",
+			golden: "timetable-ar.html",
+			write: func(sb *strings.Builder, options TimetableOptions) error {
+				return WriteHTML(sb, testSchedules(schedules, nil), options)
+			},
+			options: TimetableOptions{Title: "مواقيت الصلاة", TimeLayout: "15:04", Language: domain.HijriLanguageAr},
+		},
+		{
+			name:   "Markdown",
+			golden: "timetable.md",
+			write: func(sb *strings.Builder, options TimetableOptions) error {
+				return WriteMarkdown(sb, testSchedules(schedules, nil), options)
+			},
+			options: TimetableOptions{Title: "Prayer times", TimeLayout: "3:04 pm"},
+		},
+		{
+			name:   "Arabic markdown",
+			golden: "timetable-ar.md",
+			write: func(sb *strings.Builder, options TimetableOptions) error {
+				return WriteMarkdown(sb, testSchedules(schedules, nil), options)
+			},
+			options: TimetableOptions{TimeLayout: "15:04", Language: domain.HijriLanguageAr},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := tt.write(&sb, tt.options); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			assertGolden(t, tt.golden, sb.String())
+		})
+	}
+}
+
+func TestWriteTimetableStopsAtError(t *testing.T) {
+	schedules := testSchedules([]domain.DailyPrayerSchedule{testSchedule(2026, 3, 19)}, errors.New("missing day"))
+
+	var sb strings.Builder
+	if err := WriteHTML(&sb, schedules, TimetableOptions{}); err == nil {
+		t.Errorf("Expected html error but got none")
+	}
+	if err := WriteMarkdown(&sb, schedules, TimetableOptions{}); err == nil {
+		t.Errorf("Expected markdown error but got none")
+	}
+	if sb.Len() != 0 {
+		t.Errorf("Expected nothing written on error, got %q", sb.String())
+	}
+}
+
+func TestCollectTimetable(t *testing.T) {
+	withoutSunrise := testSchedule(2026, 3, 20)
+	withoutSunrise.Sunrise = time.Time{}
+
+	months, err := collectTimetable(
+		testSchedules([]domain.DailyPrayerSchedule{testSchedule(2026, 3, 19), withoutSunrise, testSchedule(2026, 4, 1)}, nil),
+		TimetableOptions{TimeLayout: "15:04"},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if len(months) != 2 || months[0].Title != "March 2026" || months[1].Title != "April 2026" {
+		t.Fatalf("Expected march and april tables but got %+v", months)
+	}
+	if months[0].HijriTitle != "30 Ramadan 1447 AH – 1 Shawwal 1447 AH" {
+		t.Errorf("Unexpected hijri title %q", months[0].HijriTitle)
+	}
+
+	expectedTimes := []string{"05:01", "", "12:01", "15:29", "17:38", "19:03"}
+	if got := months[0].Rows[1].Times; strings.Join(got, ",") != strings.Join(expectedTimes, ",") {
+		t.Errorf("Expected times %v but got %v", expectedTimes, got)
+	}
+	if !months[0].Rows[1].Friday || months[0].Rows[0].Friday {
+		t.Errorf("Expected only 20/03 to be friday")
+	}
+}
@@ -0,0 +1,194 @@
+package feed
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/export"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// MaxDays caps days a calendar spans, so one request can not load years of data
+const MaxDays = 366
+
+// missingYearRetry is how long a year without data is not looked up again,
+// so requests do not each try to fetch data that is not published yet
+const missingYearRetry = time.Hour
+
+// Server serves rolling ics calendars that calendar apps subscribe to
+type Server struct {
+	// Location is the only location served, as /calendar/{Location}.ics
+	Location string
+
+	// Days calendar spans starting today, when request does not set it
+	Days int
+
+	// Duration of each prayer event, when request does not set it
+	Duration time.Duration
+
+	// CreateRepo is called on each request so data updated on disk is picked up
+	CreateRepo func() domain.PrayerTimesRepo
+
+	Now func() time.Time
+
+	mu sync.Mutex
+	// missing keeps when years without data were last looked up
+	missing map[int]time.Time
+}
+
+// Handler returns handler of GET /calendar/{location}.ics. Supported query params:
+//
+//	prayers=fajr,maghrib   prayers to include, default all
+//	alarm=10,5             reminders in minutes before each prayer
+//	duration=20            minutes each prayer event blocks
+//	days=60                number of days starting today
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar/{file}", s.serveCalendar)
+	return mux
+}
+
+func (s *Server) serveCalendar(w http.ResponseWriter, r *http.Request) {
+	location, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok || !strings.EqualFold(location, s.Location) {
+		http.NotFound(w, r)
+		return
+	}
+
+	options, days, err := s.parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := s.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 0, days-1)
+	repo := s.CreateRepo()
+
+	to, versions, err := s.availableRange(repo, from, to, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	etag := s.etag(versions, from, to, r.URL.RawQuery)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%v", int(untilTomorrow(now).Seconds())))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// write to buffer first, so errors are reported with a proper status code
+	options.Location = s.Location
+	options.Stamp = now
+	var buf bytes.Buffer
+	if err := export.WriteICS(&buf, repo.GetSchedules(from, to), options); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// parseQuery reads ics options and days from request query params
+func (s *Server) parseQuery(r *http.Request) (export.ICSOptions, int, error) {
+	query := r.URL.Query()
+	options := export.ICSOptions{
+		Name:     fmt.Sprintf("Prayer times, %v", s.Location),
+		Duration: s.Duration,
+	}
+
+	if prayers := query.Get("prayers"); prayers != "" {
+		options.Prayers = strings.Split(prayers, ",")
+		for _, p := range options.Prayers {
+			if !slices.ContainsFunc(models.SortedPrayerNames, func(name string) bool { return strings.EqualFold(name, p) }) {
+				return export.ICSOptions{}, 0, fmt.Errorf("invalid prayer %q", p)
+			}
+		}
+	}
+
+	if alarms := query.Get("alarm"); alarms != "" {
+		for _, a := range strings.Split(alarms, ",") {
+			minutes, err := strconv.Atoi(a)
+			if err != nil || minutes < 0 {
+				return export.ICSOptions{}, 0, fmt.Errorf("invalid alarm %q, expected minutes before prayer", a)
+			}
+			options.Alarms = append(options.Alarms, time.Duration(minutes)*time.Minute)
+		}
+	}
+
+	if duration := query.Get("duration"); duration != "" {
+		minutes, err := strconv.Atoi(duration)
+		if err != nil || minutes <= 0 {
+			return export.ICSOptions{}, 0, fmt.Errorf("invalid duration %q, expected minutes", duration)
+		}
+		options.Duration = time.Duration(minutes) * time.Minute
+	}
+
+	days := s.Days
+	if daysStr := query.Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days <= 0 || days > MaxDays {
+			return export.ICSOptions{}, 0, fmt.Errorf("invalid days %q, expected 1 to %v", daysStr, MaxDays)
+		}
+	}
+	return options, days, nil
+}
+
+// availableRange returns data versions of years from @from to @to, and @to
+// moved back to end of last year that has data. Only data of first year is
+// required, calendar ends early when data of next year is not published yet
+func (s *Server) availableRange(repo domain.PrayerTimesRepo, from time.Time, to time.Time, now time.Time) (time.Time, []string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var versions []string
+	for year := from.Year(); year <= to.Year(); year++ {
+		if year > from.Year() && now.Sub(s.missing[year]) < missingYearRetry {
+			return time.Date(year-1, 12, 31, 0, 0, 0, 0, to.Location()), versions, nil
+		}
+		version, err := repo.GetDataVersion(year)
+		if err != nil && year == from.Year() {
+			return time.Time{}, nil, err
+		}
+		if err != nil {
+			if s.missing == nil {
+				s.missing = map[int]time.Time{}
+			}
+			s.missing[year] = now
+			return time.Date(year-1, 12, 31, 0, 0, 0, 0, to.Location()), versions, nil
+		}
+		delete(s.missing, year)
+		versions = append(versions, fmt.Sprintf("%v:%v", year, version))
+	}
+	return to, versions, nil
+}
+
+// etag changes when data of any year in range changes, when range moves to
+// next day or ends at another day, or when request asks for different options
+func (s *Server) etag(versions []string, from time.Time, to time.Time, query string) string {
+	hash := sha1.New()
+	for _, version := range versions {
+		fmt.Fprintln(hash, version)
+	}
+	fmt.Fprintf(hash, "%v\n%v\n%v\n%v\n", from.Format(time.DateOnly), to.Format(time.DateOnly), s.Location, query)
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`
+}
+
+// untilTomorrow returns time left to next midnight, when rolling range moves
+func untilTomorrow(now time.Time) time.Duration {
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	return tomorrow.Sub(now)
+}
@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage"
+	"github.com/mabd-dev/prayer-times-cli/internal/data/storage/storagetest"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestServer returns server of 2026 data, with @sha1 as data version
+func createTestServer(sha1 string) *Server {
+	data := models.PrayerTimesResponse{Sha1: sha1}
+	for day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local); day.Year() == 2026; day = day.AddDate(0, 0, 1) {
+		data.Year = append(data.Year, models.DailyPrayersDto{
+			Gregorian: day.Format("02/01/2006"),
+			Prayers: models.PrayerTimesDto{
+				Fajr:    "05:00 am",
+				Dhuhr:   "12:00 pm",
+				Asr:     "03:00 pm",
+				Maghrib: "06:00 pm",
+				Isha:    "07:30 pm",
+			},
+		})
+	}
+
+	return &Server{
+		Location: "beirut",
+		Days:     60,
+		Duration: 20 * time.Minute,
+		CreateRepo: func() domain.PrayerTimesRepo {
+			provider := func(year int) storage.Storage { return &storagetest.MemoryStorage{Data: data} }
+			return domain.CreatePrayerTimesRepo(provider, nil, domain.HijriOptions{})
+		},
+		Now: func() time.Time {
+			return time.Date(2026, 3, 19, 10, 0, 0, 0, time.Local)
+		},
+	}
+}
+
+func TestServeCalendar(t *testing.T) {
+	server := httptest.NewServer(createTestServer("sha-1").Handler())
+	defer server.Close()
+
+	t.Run("Filters prayers and adds alarms", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/calendar/beirut.ics?prayers=fajr,Maghrib&alarm=10,5&days=2&duration=30")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.NotEmpty(t, resp.Header.Get("ETag"))
+		assert.Equal(t, "public, max-age=50400", resp.Header.Get("Cache-Control"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		ics := string(body)
+
+		assert.Equal(t, 4, strings.Count(ics, "BEGIN:VEVENT"), "2 days of 2 prayers")
+		assert.Equal(t, 8, strings.Count(ics, "BEGIN:VALARM"), "2 alarms per event")
+		assert.Contains(t, ics, "UID:20260319-fajr-beirut@prayer-times-cli")
+		assert.Contains(t, ics, "UID:20260320-maghrib-beirut@prayer-times-cli")
+		assert.NotContains(t, ics, "SUMMARY:Asr")
+	})
+
+	t.Run("Not modified when etag matches", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/calendar/beirut.ics")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/calendar/beirut.ics", nil)
+		require.NoError(t, err)
+		req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("Unknown location", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/calendar/london.ics")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Invalid query params", func(t *testing.T) {
+		for _, query := range []string{"prayers=shuruq", "alarm=-5", "duration=0", "days=1000"} {
+			resp, err := http.Get(server.URL + "/calendar/beirut.ics?" + query)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestETagFollowsDataVersion(t *testing.T) {
+	etagOf := func(server *Server, query string) string {
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/calendar/beirut.ics"+query, nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		return recorder.Header().Get("ETag")
+	}
+
+	first := etagOf(createTestServer("sha-1"), "")
+	assert.Equal(t, first, etagOf(createTestServer("sha-1"), ""), "same data, same etag")
+	assert.NotEqual(t, first, etagOf(createTestServer("sha-2"), ""), "data changed")
+	assert.NotEqual(t, first, etagOf(createTestServer("sha-1"), "?alarm=10"), "options changed")
+}
+
+// missingYearRepo is repo of @year data only, counting lookups of later years
+type missingYearRepo struct {
+	domain.PrayerTimesRepo
+	year    int
+	lookups *int
+}
+
+func (r missingYearRepo) GetDataVersion(year int) (string, error) {
+	if year > r.year {
+		*r.lookups++
+		return "", errors.New("Failed to get year prayers")
+	}
+	return r.PrayerTimesRepo.GetDataVersion(year)
+}
+
+func TestServeCalendarEndsAtMissingYear(t *testing.T) {
+	server := createTestServer("sha-1")
+	createRepo := server.CreateRepo
+	lookups := 0
+	server.CreateRepo = func() domain.PrayerTimesRepo {
+		return missingYearRepo{PrayerTimesRepo: createRepo(), year: 2026, lookups: &lookups}
+	}
+	now := time.Date(2026, 12, 1, 10, 0, 0, 0, time.Local)
+	server.Now = func() time.Time { return now }
+
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/calendar/beirut.ics?prayers=fajr", nil))
+		return recorder
+	}
+
+	recorder := serve()
+	require.Equal(t, http.StatusOK, recorder.Code)
+	ics := recorder.Body.String()
+	assert.Equal(t, 31, strings.Count(ics, "BEGIN:VEVENT"), "days up to end of 2026")
+	assert.Contains(t, ics, "UID:20261231-fajr-beirut@prayer-times-cli")
+	assert.NotContains(t, ics, "UID:20270101")
+
+	// missing year is not looked up on each request
+	assert.Equal(t, http.StatusOK, serve().Code)
+	assert.Equal(t, 1, lookups)
+
+	now = now.Add(missingYearRetry)
+	assert.Equal(t, http.StatusOK, serve().Code)
+	assert.Equal(t, 2, lookups)
+}
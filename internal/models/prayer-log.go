@@ -0,0 +1,6 @@
+package models
+
+// PrayerLogDto maps days, like 2026-03-20, to names of prayers marked as prayed
+type PrayerLogDto struct {
+	Days map[string][]string `json:"days"`
+}
@@ -2,6 +2,7 @@ package models
 
 type PrayerTimesDto struct {
 	Fajr    string `json:"fajr"`
+	Sunrise string `json:"sunrise,omitempty"`
 	Dhuhr   string `json:"dhuhr"`
 	Asr     string `json:"asr"`
 	Maghrib string `json:"maghrib"`
@@ -48,4 +49,14 @@ var (
 		"Maghrib",
 		"Isha",
 	}
+
+	// ArabicPrayerNames maps prayer names, and sunrise, to arabic
+	ArabicPrayerNames = map[string]string{
+		"Fajr":    "الفجر",
+		"Sunrise": "الشروق",
+		"Dhuhr":   "الظهر",
+		"Asr":     "العصر",
+		"Maghrib": "المغرب",
+		"Isha":    "العشاء",
+	}
 )
@@ -0,0 +1,23 @@
+package models
+
+// SnapshotDto is a tiny copy of today and tomorrow prayer times, so shell
+// prompts can read it on every redraw without loading a year of data
+type SnapshotDto struct {
+	CreatedAt string           `json:"createdAt"`
+	Days      []SnapshotDayDto `json:"days"`
+}
+
+type SnapshotDayDto struct {
+	// Date like 2026-03-20
+	Date    string              `json:"date"`
+	Hijri   string              `json:"hijri,omitempty"`
+	Event   Event               `json:"event"`
+	Prayers []SnapshotPrayerDto `json:"prayers"`
+}
+
+type SnapshotPrayerDto struct {
+	Name string `json:"name"`
+
+	// Time in RFC 3339, with offset
+	Time string `json:"time"`
+}
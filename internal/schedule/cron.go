@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	cronBegin = "# BEGIN prayers schedule, generated by prayers schedule install"
+	cronEnd   = "# END prayers schedule"
+)
+
+// CrontabBlock returns crontab lines calling fire at each event time, and
+// installing lines of coming days again daily, between marker comments.
+// Cron has no year field, lines are only valid until next refresh
+func CrontabBlock(times []time.Time, options Options) string {
+	// % starts a new line in crontab, even quoted
+	command := strings.ReplaceAll(shellQuote(options.Command), "%", `\%`)
+	hijriArgs := options.hijriArgs()
+
+	var sb strings.Builder
+	sb.WriteString(cronBegin + "\n")
+	for _, t := range times {
+		fmt.Fprintf(&sb, "%v %v %v %v * %v fire %v%v\n", t.Minute(), t.Hour(), t.Day(), int(t.Month()), command, t.Format(EventLayout), hijriArgs)
+	}
+	fmt.Fprintf(&sb, "5 0 * * * %v schedule install --cron --days %v%v\n", command, options.Days, hijriArgs)
+	sb.WriteString(cronEnd + "\n")
+	return sb.String()
+}
+
+// ReplaceCrontabBlock removes block of previous install from @crontab and
+// appends @block, which is empty to uninstall
+func ReplaceCrontabBlock(crontab string, block string) string {
+	var lines []string
+	inBlock := false
+	for _, line := range strings.Split(crontab, "\n") {
+		switch {
+		case line == cronBegin:
+			inBlock = true
+		case line == cronEnd:
+			inBlock = false
+		case !inBlock:
+			lines = append(lines, line)
+		}
+	}
+
+	result := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if result != "" {
+		result += "\n"
+	}
+	return result + block
+}
+
+// shellQuote quotes @s for sh if it has special chars
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " \t\"'$`\\*?;&|<>()") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,42 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCrontabBlock(t *testing.T) {
+	assertGolden(t, "crontab", CrontabBlock(testTimes(), Options{Command: "/usr/local/bin/prayers", Days: 2, HijriLang: "ar", HijriOffset: -1, HijriMaghrib: true}))
+
+	block := CrontabBlock(nil, Options{Command: "/home/me/my apps/100%/prayers", Days: 1})
+	expected := "5 0 * * * '/home/me/my apps/100\\%/prayers' schedule install --cron --days 1\n"
+	if !strings.Contains(block, expected) {
+		t.Errorf("Expected %q in\n%v", expected, block)
+	}
+}
+
+func TestReplaceCrontabBlock(t *testing.T) {
+	block := cronBegin + "\n0 12 20 3 * prayers fire 20260320T1200\n" + cronEnd + "\n"
+	newBlock := cronBegin + "\n0 12 21 3 * prayers fire 20260321T1200\n" + cronEnd + "\n"
+
+	tests := []struct {
+		name     string
+		crontab  string
+		block    string
+		expected string
+	}{
+		{name: "Empty crontab", block: newBlock, expected: newBlock},
+		{name: "Other lines kept", crontab: "MAILTO=me\n0 * * * * backup\n", block: newBlock, expected: "MAILTO=me\n0 * * * * backup\n" + newBlock},
+		{name: "Previous block replaced", crontab: "0 * * * * backup\n" + block + "30 1 * * * sync\n", block: newBlock, expected: "0 * * * * backup\n30 1 * * * sync\n" + newBlock},
+		{name: "Uninstall", crontab: "0 * * * * backup\n" + block, expected: "0 * * * * backup\n"},
+		{name: "Uninstall only block", crontab: block},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ReplaceCrontabBlock(tt.crontab, tt.block); result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
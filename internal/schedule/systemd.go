@@ -0,0 +1,201 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventLayout formats event times in unit names and fire command args
+const EventLayout = "20060102T1504"
+
+const (
+	unitPrefix     = "prayers-"
+	eventService   = "prayers-event@.service"
+	refreshTimer   = "prayers-refresh.timer"
+	refreshService = "prayers-refresh.service"
+	generatedNote  = "# Generated by prayers schedule install, changes are overwritten\n"
+)
+
+// Options of generated units and crontab lines
+type Options struct {
+	// Command runs prayers, e.g. its absolute path
+	Command string
+
+	// Days ahead events are scheduled for, refreshed daily
+	Days int
+
+	// Hijri flags passed to every command, so events carry same hijri date
+	// as daemon would. Zero values are left out
+	HijriLang    string
+	HijriOffset  int
+	HijriMaghrib bool
+}
+
+// hijriArgs returns hijri flags of @o with a leading space, or empty string
+func (o Options) hijriArgs() string {
+	var sb strings.Builder
+	if o.HijriLang != "" {
+		fmt.Fprintf(&sb, " --hijri-lang=%v", o.HijriLang)
+	}
+	if o.HijriOffset != 0 {
+		fmt.Fprintf(&sb, " --hijri-offset=%v", o.HijriOffset)
+	}
+	if o.HijriMaghrib {
+		sb.WriteString(" --hijri-maghrib")
+	}
+	return sb.String()
+}
+
+// Unit is a systemd unit file
+type Unit struct {
+	Name    string
+	Content string
+}
+
+// SystemdUnits returns a timer per event time calling fire through one
+// template service, and a daily timer installing units of coming days again
+func SystemdUnits(times []time.Time, options Options) []Unit {
+	command := systemdQuote(options.Command)
+	hijriArgs := options.hijriArgs()
+	units := []Unit{
+		{
+			Name: eventService,
+			Content: generatedNote + `[Unit]
+Description=Prayer times event at %i
+
+[Service]
+Type=oneshot
+ExecStart=` + command + ` fire %i` + hijriArgs + `
+`,
+		},
+		{
+			Name: refreshService,
+			Content: generatedNote + `[Unit]
+Description=Schedule prayer times events of coming days
+
+[Service]
+Type=oneshot
+ExecStart=` + fmt.Sprintf("%v schedule install --days %v%v", command, options.Days, hijriArgs) + `
+`,
+		},
+		{
+			Name: refreshTimer,
+			Content: generatedNote + `[Unit]
+Description=Schedule prayer times events of coming days, daily
+
+[Timer]
+OnCalendar=*-*-* 00:05:00
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`,
+		},
+	}
+
+	for _, t := range times {
+		id := t.Format(EventLayout)
+		units = append(units, Unit{
+			Name: fmt.Sprintf("%vevent-%v.timer", unitPrefix, id),
+			Content: generatedNote + fmt.Sprintf(`[Unit]
+Description=Prayer times event at %v
+
+[Timer]
+OnCalendar=%v
+AccuracySec=1s
+Unit=prayers-event@%v.service
+
+[Install]
+WantedBy=timers.target
+`, t.Format("2006-01-02 15:04"), t.Format("2006-01-02 15:04:05"), id),
+		})
+	}
+	return units
+}
+
+// Timers returns names of timer units in @units
+func Timers(units []Unit) []string {
+	var timers []string
+	for _, unit := range units {
+		if strings.HasSuffix(unit.Name, ".timer") {
+			timers = append(timers, unit.Name)
+		}
+	}
+	return timers
+}
+
+// InstalledUnits returns names of generated units in @dir
+func InstalledUnits(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, unitPrefix) && (strings.HasSuffix(name, ".timer") || strings.HasSuffix(name, ".service")) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// StaleUnits returns names of generated units in @dir that are not in @units
+func StaleUnits(dir string, units []Unit) ([]string, error) {
+	installed, err := InstalledUnits(dir)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(installed, func(name string) bool {
+		return slices.ContainsFunc(units, func(unit Unit) bool { return unit.Name == name })
+	}), nil
+}
+
+// WriteSystemdUnits writes @units to @dir, removing stale generated units
+func WriteSystemdUnits(dir string, units []Unit) error {
+	stale, err := StaleUnits(dir, units)
+	if err != nil {
+		return err
+	}
+	if err := RemoveUnits(dir, stale); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, unit := range units {
+		if err := os.WriteFile(filepath.Join(dir, unit.Name), []byte(unit.Content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveUnits removes units named @names from @dir
+func RemoveUnits(dir string, names []string) error {
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// systemdQuote quotes @s for ExecStart if it has spaces, escaping specifiers
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if !strings.ContainsAny(s, " \t\"") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
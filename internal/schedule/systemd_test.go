@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// assertGolden compares @actual to testdata/@name, or overwrites it with -update
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if actual != string(expected) {
+		t.Errorf("Output does not match %v, run tests with -update if change is expected\nExpected:\n%v\nGot:\n%v", path, string(expected), actual)
+	}
+}
+
+func testTimes() []time.Time {
+	return []time.Time{
+		time.Date(2026, 3, 20, 15, 12, 0, 0, time.UTC),
+		time.Date(2026, 3, 21, 4, 45, 0, 0, time.UTC),
+	}
+}
+
+func TestSystemdUnits(t *testing.T) {
+	units := SystemdUnits(testTimes(), Options{Command: "/usr/local/bin/prayers", Days: 2, HijriLang: "ar", HijriOffset: -1, HijriMaghrib: true})
+
+	expectedNames := []string{
+		"prayers-event@.service",
+		"prayers-refresh.service",
+		"prayers-refresh.timer",
+		"prayers-event-20260320T1512.timer",
+		"prayers-event-20260321T0445.timer",
+	}
+	var names []string
+	for _, unit := range units {
+		names = append(names, unit.Name)
+		assertGolden(t, unit.Name, unit.Content)
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected units %v but got %v", expectedNames, names)
+	}
+}
+
+func TestSystemdUnitsQuoteCommand(t *testing.T) {
+	units := SystemdUnits(nil, Options{Command: "/home/me/my apps/100%/prayers", Days: 1})
+	expected := "ExecStart=\"/home/me/my apps/100%%/prayers\" fire %i\n"
+	if !strings.Contains(units[0].Content, expected) {
+		t.Errorf("Expected %q in\n%v", expected, units[0].Content)
+	}
+}
+
+func TestWriteSystemdUnits(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"prayers-event-20260319T1200.timer", "other.timer"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	units := SystemdUnits(testTimes(), Options{Command: "prayers", Days: 2})
+
+	stale, err := StaleUnits(dir, units)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(stale, []string{"prayers-event-20260319T1200.timer"}) {
+		t.Errorf("Expected stale event timer but got %v", stale)
+	}
+
+	if err := WriteSystemdUnits(dir, units); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(units)+1 {
+		t.Errorf("Expected %v units and other.timer but got %v", len(units), entries)
+	}
+
+	installed, err := InstalledUnits(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveUnits(dir, installed); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if installed, _ := InstalledUnits(dir); len(installed) != 0 {
+		t.Errorf("Expected no units left but got %v", installed)
+	}
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// DefaultBarFormat is text shown in bars when no format is given
+const DefaultBarFormat = "{{.Name}} {{.Remaining}}"
+
+type BarMode string
+
+const (
+	BarWaybar   BarMode = "waybar"
+	BarI3blocks BarMode = "i3blocks"
+	BarPolybar  BarMode = "polybar"
+	BarTmux     BarMode = "tmux"
+)
+
+// ParseBarMode returns error if @s is not one of supported bars
+func ParseBarMode(s string) (BarMode, error) {
+	switch BarMode(s) {
+	case BarWaybar, BarI3blocks, BarPolybar, BarTmux:
+		return BarMode(s), nil
+	}
+	return "", fmt.Errorf("invalid mode %q, expected waybar, i3blocks, polybar or tmux", s)
+}
+
+// BarState is how close current time is to a prayer, bars style each differently
+type BarState string
+
+const (
+	BarStateNormal BarState = ""
+
+	// BarStateImminent is when next prayer is within @BarOptions.Imminent
+	BarStateImminent BarState = "imminent"
+
+	// BarStateNow is when previous prayer started within @BarOptions.Now
+	BarStateNow BarState = "now"
+)
+
+type BarOptions struct {
+	Mode BarMode
+
+	// Format is go template of bar text, with same fields as next command
+	Format string
+
+	// Imminent is how long before next prayer it is imminent
+	Imminent time.Duration
+
+	// Now is how long after a prayer starts it is still now
+	Now time.Duration
+
+	// ImminentColor and NowColor are hex colors, like #ff5555, used by bars
+	// that do not style with classes
+	ImminentColor string
+	NowColor      string
+}
+
+type waybarOutput struct {
+	Text       string   `json:"text"`
+	Tooltip    string   `json:"tooltip"`
+	Class      []string `json:"class"`
+	Percentage int      `json:"percentage"`
+}
+
+// FormatBar returns @activePrayerTracking in native format of @options.Mode:
+//
+//	waybar    json with text, tooltip holding day table, class and percentage
+//	i3blocks  full text, short text and color lines
+//	polybar   %{F#color} markup
+//	tmux      #[fg=#color] markup
+func FormatBar(activePrayerTracking domain.ActivePrayerTracking, options BarOptions) (string, error) {
+	format := options.Format
+	if format == "" {
+		format = DefaultBarFormat
+	}
+	text, err := ExecuteTemplate(format, newNextPrayer(activePrayerTracking, false))
+	if err != nil {
+		return "", err
+	}
+
+	state := GetBarState(activePrayerTracking, options)
+	color := ""
+	switch state {
+	case BarStateImminent:
+		color = options.ImminentColor
+	case BarStateNow:
+		color = options.NowColor
+	}
+
+	switch options.Mode {
+	case BarWaybar:
+		class := []string{strings.ToLower(activePrayerTracking.NextPrayer)}
+		if state != BarStateNormal {
+			class = append(class, string(state))
+		}
+		var sb strings.Builder
+		encoder := json.NewEncoder(&sb)
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(waybarOutput{
+			Text:       text,
+			Tooltip:    formatBarTooltip(activePrayerTracking),
+			Class:      class,
+			Percentage: int(activePrayerTracking.Progress),
+		})
+		return strings.TrimSuffix(sb.String(), "\n"), err
+
+	case BarI3blocks:
+		lines := []string{text, FormatDurationShort(activePrayerTracking.TimeRemaining)}
+		if color != "" {
+			lines = append(lines, color)
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case BarPolybar:
+		if color == "" {
+			return text, nil
+		}
+		// literal "%" is written as "%%" in polybar markup
+		return fmt.Sprintf("%%{F%v}%v%%{F-}", color, strings.ReplaceAll(text, "%", "%%")), nil
+
+	case BarTmux:
+		// "#" starts tmux formats, "##" is a literal one
+		text = strings.ReplaceAll(text, "#", "##")
+		if color == "" {
+			return text, nil
+		}
+		return fmt.Sprintf("#[fg=%v]%v#[default]", color, text), nil
+	}
+	return "", fmt.Errorf("invalid mode %q", options.Mode)
+}
+
+// GetBarState returns now if previous prayer started within @options.Now,
+// imminent if next prayer is within @options.Imminent, otherwise normal
+func GetBarState(activePrayerTracking domain.ActivePrayerTracking, options BarOptions) BarState {
+	sincePrevious := activePrayerTracking.NextPrayerTime.Sub(activePrayerTracking.PreviousPrayerTime) - activePrayerTracking.TimeRemaining
+	if sincePrevious < options.Now {
+		return BarStateNow
+	}
+	if activePrayerTracking.TimeRemaining <= options.Imminent {
+		return BarStateImminent
+	}
+	return BarStateNormal
+}
+
+// formatBarTooltip returns date, event and prayer times of the day in pango
+// markup, with next prayer in bold
+func formatBarTooltip(activePrayerTracking domain.ActivePrayerTracking) string {
+	var sb strings.Builder
+	sb.WriteString(activePrayerTracking.Date.Format("Monday 02/01/2006"))
+	if hijri := activePrayerTracking.Hijri.Format(HijriLanguage); hijri != "" {
+		fmt.Fprintf(&sb, "\n%v", html.EscapeString(hijri))
+	}
+	if !activePrayerTracking.Event.IsZero() {
+		fmt.Fprintf(&sb, "\n%v", html.EscapeString(activePrayerTracking.Event.Name(HijriLanguage)))
+	}
+	sb.WriteString("\n")
+
+	line := func(name string, t time.Time) {
+		row := fmt.Sprintf("%-8v %8v", name, t.Format(TimeLayout))
+		if name == activePrayerTracking.NextPrayer && t.Equal(activePrayerTracking.NextPrayerTime) {
+			row = "<b>" + row + "</b>"
+		}
+		fmt.Fprintf(&sb, "\n<tt>%v</tt>", row)
+	}
+	for _, p := range activePrayerTracking.Prayers {
+		line(p.Name, p.Time)
+		if p.Name == models.SortedPrayerNames[0] && !activePrayerTracking.Sunrise.IsZero() {
+			line("Sunrise", activePrayerTracking.Sunrise)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+func testTracking(remaining time.Duration) domain.ActivePrayerTracking {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 20, hour, minute, 0, 0, time.UTC)
+	}
+	return domain.ActivePrayerTracking{
+		DailyPrayerSchedule: domain.DailyPrayerSchedule{
+			Date:  at(0, 0),
+			Hijri: domain.HijriDate{Year: 1447, Month: 10, Day: 1},
+			Event: domain.Event{En: "Eid al-Fitr", Ar: "عيد الفطر"},
+			Prayers: []domain.Prayer{
+				{Name: "Fajr", Time: at(5, 1)},
+				{Name: "Dhuhr", Time: at(12, 1)},
+				{Name: "Asr", Time: at(15, 29)},
+				{Name: "Maghrib", Time: at(17, 38)},
+				{Name: "Isha", Time: at(19, 3)},
+			},
+			Sunrise: at(6, 16),
+		},
+		PreviousPrayer:     "Dhuhr",
+		PreviousPrayerTime: at(12, 1),
+		NextPrayer:         "Asr",
+		NextPrayerTime:     at(15, 29),
+		TimeRemaining:      remaining,
+		Progress:           50,
+	}
+}
+
+func TestFormatBar(t *testing.T) {
+	options := BarOptions{
+		Imminent:      15 * time.Minute,
+		Now:           10 * time.Minute,
+		ImminentColor: "#ffb86c",
+		NowColor:      "#50fa7b",
+	}
+
+	tests := []struct {
+		name      string
+		mode      BarMode
+		format    string
+		remaining time.Duration
+		expected  string
+	}{
+		{name: "i3blocks normal", mode: BarI3blocks, remaining: time.Hour + 12*time.Minute, expected: "Asr 1h12m\n1h12m"},
+		{name: "i3blocks imminent", mode: BarI3blocks, remaining: 5 * time.Minute, expected: "Asr 5m\n5m\n#ffb86c"},
+		{name: "polybar normal", mode: BarPolybar, remaining: time.Hour, expected: "Asr 1h0m"},
+		{name: "polybar now", mode: BarPolybar, format: "{{.Name}} 100%", remaining: 3*time.Hour + 25*time.Minute, expected: "%{F#50fa7b}Asr 100%%%{F-}"},
+		{name: "tmux imminent", mode: BarTmux, format: "#{{.Name}}", remaining: time.Minute, expected: "#[fg=#ffb86c]##Asr#[default]"},
+		{name: "tmux normal", mode: BarTmux, remaining: time.Hour, expected: "Asr 1h0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := options
+			options.Mode = tt.mode
+			options.Format = tt.format
+
+			result, err := FormatBar(testTracking(tt.remaining), options)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatBarWaybar(t *testing.T) {
+	tests := []struct {
+		name          string
+		remaining     time.Duration
+		expectedClass []string
+	}{
+		{name: "Normal", remaining: time.Hour, expectedClass: []string{"asr"}},
+		{name: "Imminent", remaining: 10 * time.Minute, expectedClass: []string{"asr", "imminent"}},
+		{name: "Now", remaining: 3*time.Hour + 25*time.Minute, expectedClass: []string{"asr", "now"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatBar(testTracking(tt.remaining), BarOptions{Mode: BarWaybar, Imminent: 15 * time.Minute, Now: 10 * time.Minute})
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			var output waybarOutput
+			if err := json.Unmarshal([]byte(result), &output); err != nil {
+				t.Fatalf("Expected json but got %q: %v", result, err)
+			}
+			if !reflect.DeepEqual(output.Class, tt.expectedClass) {
+				t.Errorf("Expected class %v but got %v", tt.expectedClass, output.Class)
+			}
+			if output.Percentage != 50 {
+				t.Errorf("Expected percentage 50 but got %v", output.Percentage)
+			}
+
+			expectedTooltip := "Friday 20/03/2026\n1 Shawwal 1447 AH\nEid al-Fitr\n" +
+				"\n<tt>Fajr      5:01 am</tt>" +
+				"\n<tt>Sunrise   6:16 am</tt>" +
+				"\n<tt>Dhuhr    12:01 pm</tt>" +
+				"\n<tt><b>Asr       3:29 pm</b></tt>" +
+				"\n<tt>Maghrib   5:38 pm</tt>" +
+				"\n<tt>Isha      7:03 pm</tt>"
+			if output.Tooltip != expectedTooltip {
+				t.Errorf("Expected tooltip\n%v\nbut got\n%v", expectedTooltip, output.Tooltip)
+			}
+		})
+	}
+}
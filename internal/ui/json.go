@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// JSONSchemaVersion is bumped on every breaking change to json output. Adding
+// new fields is not a breaking change
+const JSONSchemaVersion = 1
+
+type OutputFormat string
+
+const (
+	OutputTable  OutputFormat = "table"
+	OutputJSON   OutputFormat = "json"
+	OutputNDJSON OutputFormat = "ndjson"
+)
+
+// ParseOutputFormat returns error if @s is not one of supported formats
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(s) {
+	case OutputTable, OutputJSON, OutputNDJSON:
+		return OutputFormat(s), nil
+	}
+	return "", fmt.Errorf("invalid output %q, expected table, json or ndjson", s)
+}
+
+type prayerJSON struct {
+	Name string `json:"name"`
+	Time string `json:"time"`
+}
+
+type hijriJSON struct {
+	Date        string `json:"date"`
+	Year        int    `json:"year"`
+	Month       int    `json:"month"`
+	Day         int    `json:"day"`
+	MonthName   string `json:"monthName"`
+	MonthNameAr string `json:"monthNameAr"`
+}
+
+type eventJSON struct {
+	En string `json:"en"`
+	Ar string `json:"ar"`
+}
+
+type scheduleJSON struct {
+	Date    string       `json:"date"`
+	Weekday string       `json:"weekday"`
+	Hijri   *hijriJSON   `json:"hijri"`
+	Event   *eventJSON   `json:"event"`
+	Sunrise *string      `json:"sunrise"`
+	Prayers []prayerJSON `json:"prayers"`
+}
+
+type trackingJSON struct {
+	scheduleJSON
+	Next             prayerJSON `json:"next"`
+	Previous         prayerJSON `json:"previous"`
+	RemainingSeconds int        `json:"remainingSeconds"`
+	Progress         float64    `json:"progress"`
+}
+
+type dayEventJSON struct {
+	Date     string     `json:"date"`
+	Hijri    *hijriJSON `json:"hijri"`
+	Event    eventJSON  `json:"event"`
+	DaysLeft int        `json:"daysLeft"`
+}
+
+// Top level documents, each one carries schema version
+
+type scheduleDocument struct {
+	SchemaVersion int `json:"schemaVersion"`
+	scheduleJSON
+}
+
+type trackingDocument struct {
+	SchemaVersion int `json:"schemaVersion"`
+	trackingJSON
+}
+
+type schedulesDocument struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Schedules     []scheduleJSON `json:"schedules"`
+}
+
+type dayEventDocument struct {
+	SchemaVersion int `json:"schemaVersion"`
+	dayEventJSON
+}
+
+type dayEventsDocument struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Events        []dayEventJSON `json:"events"`
+}
+
+// jsonOutput is where json documents are printed to
+var jsonOutput io.Writer = os.Stdout
+
+// RenderScheduleJSON prints one schedule document
+func RenderScheduleJSON(dailyPrayerSchedule domain.DailyPrayerSchedule, format OutputFormat) error {
+	return writeJSON(scheduleDocument{
+		SchemaVersion: JSONSchemaVersion,
+		scheduleJSON:  toScheduleJSON(dailyPrayerSchedule),
+	}, format)
+}
+
+// RenderActivePrayerTrackingJSON prints one schedule document with next and
+// previous prayers, remaining seconds and progress
+func RenderActivePrayerTrackingJSON(activePrayerTracking domain.ActivePrayerTracking, format OutputFormat) error {
+	return writeJSON(trackingDocument{
+		SchemaVersion: JSONSchemaVersion,
+		trackingJSON: trackingJSON{
+			scheduleJSON: toScheduleJSON(activePrayerTracking.DailyPrayerSchedule),
+			Next: prayerJSON{
+				Name: activePrayerTracking.NextPrayer,
+				Time: activePrayerTracking.NextPrayerTime.Format(time.RFC3339),
+			},
+			Previous: prayerJSON{
+				Name: activePrayerTracking.PreviousPrayer,
+				Time: activePrayerTracking.PreviousPrayerTime.Format(time.RFC3339),
+			},
+			RemainingSeconds: int(activePrayerTracking.TimeRemaining.Seconds()),
+			Progress:         activePrayerTracking.Progress,
+		},
+	}, format)
+}
+
+// RenderSchedulesJSON prints all schedules in one document for json, or a
+// schedule document per line for ndjson
+func RenderSchedulesJSON(schedules []domain.DailyPrayerSchedule, format OutputFormat) error {
+	if format == OutputNDJSON {
+		for _, s := range schedules {
+			if err := RenderScheduleJSON(s, format); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	document := schedulesDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Schedules:     []scheduleJSON{},
+	}
+	for _, s := range schedules {
+		document.Schedules = append(document.Schedules, toScheduleJSON(s))
+	}
+	return writeJSON(document, format)
+}
+
+// RenderEventsJSON prints all events in one document for json, or an event
+// document per line for ndjson. Days left are counted from @today
+func RenderEventsJSON(events []domain.DayEvent, today time.Time, format OutputFormat) error {
+	document := dayEventsDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Events:        []dayEventJSON{},
+	}
+	for _, e := range events {
+		event := dayEventJSON{
+			Date:     e.Date.Format(time.DateOnly),
+			Hijri:    toHijriJSON(e.Hijri),
+			Event:    eventJSON{En: e.Event.En, Ar: e.Event.Ar},
+			DaysLeft: domain.DaysBetween(today, e.Date),
+		}
+		if format == OutputNDJSON {
+			err := writeJSON(dayEventDocument{SchemaVersion: JSONSchemaVersion, dayEventJSON: event}, format)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		document.Events = append(document.Events, event)
+	}
+
+	if format == OutputNDJSON {
+		return nil
+	}
+	return writeJSON(document, format)
+}
+
+// writeJSON prints @v indented for json, or in a single line for ndjson
+func writeJSON(v any, format OutputFormat) error {
+	encoder := json.NewEncoder(jsonOutput)
+	encoder.SetEscapeHTML(false)
+	if format != OutputNDJSON {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(v)
+}
+
+func toScheduleJSON(dailyPrayerSchedule domain.DailyPrayerSchedule) scheduleJSON {
+	result := scheduleJSON{
+		Date:    dailyPrayerSchedule.Date.Format(time.DateOnly),
+		Weekday: dailyPrayerSchedule.Date.Weekday().String(),
+		Hijri:   toHijriJSON(dailyPrayerSchedule.Hijri),
+		Prayers: []prayerJSON{},
+	}
+	if !dailyPrayerSchedule.Event.IsZero() {
+		result.Event = &eventJSON{En: dailyPrayerSchedule.Event.En, Ar: dailyPrayerSchedule.Event.Ar}
+	}
+	if !dailyPrayerSchedule.Sunrise.IsZero() {
+		sunrise := dailyPrayerSchedule.Sunrise.Format(time.RFC3339)
+		result.Sunrise = &sunrise
+	}
+	for _, p := range dailyPrayerSchedule.Prayers {
+		result.Prayers = append(result.Prayers, prayerJSON{
+			Name: p.Name,
+			Time: p.Time.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+func toHijriJSON(hijri domain.HijriDate) *hijriJSON {
+	if hijri.IsZero() {
+		return nil
+	}
+	return &hijriJSON{
+		Date:        hijri.String(),
+		Year:        hijri.Year,
+		Month:       hijri.Month,
+		Day:         hijri.Day,
+		MonthName:   hijri.MonthName(domain.HijriLanguageEn),
+		MonthNameAr: hijri.MonthName(domain.HijriLanguageAr),
+	}
+}
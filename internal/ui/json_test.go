@@ -0,0 +1,290 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// captureJSON returns what @render prints as json output
+func captureJSON(t *testing.T, render func() error) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := jsonOutput
+	jsonOutput = &buf
+	t.Cleanup(func() { jsonOutput = previous })
+	if err := render(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// ndjsonLines returns lines of @output, failing if one is not a json document
+func ndjsonLines(t *testing.T, output []byte) [][]byte {
+	t.Helper()
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		if !json.Valid(line) {
+			t.Fatalf("Expected json document per line but got %s", line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func expectedScheduleJSON() scheduleJSON {
+	sunrise := "2026-03-20T06:16:00Z"
+	return scheduleJSON{
+		Date:    "2026-03-20",
+		Weekday: "Friday",
+		Hijri: &hijriJSON{
+			Date:        "1447-10-01",
+			Year:        1447,
+			Month:       10,
+			Day:         1,
+			MonthName:   "Shawwal",
+			MonthNameAr: "شوال",
+		},
+		Event:   &eventJSON{En: "Eid al-Fitr", Ar: "عيد الفطر"},
+		Sunrise: &sunrise,
+		Prayers: []prayerJSON{
+			{Name: "Fajr", Time: "2026-03-20T05:01:00Z"},
+			{Name: "Dhuhr", Time: "2026-03-20T12:01:00Z"},
+			{Name: "Asr", Time: "2026-03-20T15:29:00Z"},
+			{Name: "Maghrib", Time: "2026-03-20T17:38:00Z"},
+			{Name: "Isha", Time: "2026-03-20T19:03:00Z"},
+		},
+	}
+}
+
+// assertSchemaVersion checks @document has current schema version key
+func assertSchemaVersion(t *testing.T, document []byte) {
+	t.Helper()
+	var fields map[string]any
+	if err := json.Unmarshal(document, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["schemaVersion"] != float64(JSONSchemaVersion) {
+		t.Errorf("Expected schemaVersion %v but got %v", JSONSchemaVersion, fields["schemaVersion"])
+	}
+}
+
+// assertRFC3339 checks times of @schedule are in RFC 3339
+func assertRFC3339(t *testing.T, schedule scheduleJSON) {
+	t.Helper()
+	times := []string{}
+	if schedule.Sunrise != nil {
+		times = append(times, *schedule.Sunrise)
+	}
+	for _, p := range schedule.Prayers {
+		times = append(times, p.Time)
+	}
+	for _, s := range times {
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			t.Errorf("Expected RFC 3339 time but got %v", s)
+		}
+	}
+}
+
+func TestRenderScheduleJSON(t *testing.T) {
+	output := captureJSON(t, func() error {
+		return RenderScheduleJSON(testTracking(0).DailyPrayerSchedule, OutputJSON)
+	})
+	assertSchemaVersion(t, output)
+
+	var document scheduleDocument
+	if err := json.Unmarshal(output, &document); err != nil {
+		t.Fatal(err)
+	}
+	expected := scheduleDocument{SchemaVersion: JSONSchemaVersion, scheduleJSON: expectedScheduleJSON()}
+	if !reflect.DeepEqual(document, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, document)
+	}
+	assertRFC3339(t, document.scheduleJSON)
+}
+
+func TestRenderScheduleJSONWithoutOptionalFields(t *testing.T) {
+	schedule := domain.DailyPrayerSchedule{Date: time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC)}
+	output := captureJSON(t, func() error {
+		return RenderScheduleJSON(schedule, OutputJSON)
+	})
+
+	var fields map[string]any
+	if err := json.Unmarshal(output, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"hijri", "event", "sunrise"} {
+		if value, ok := fields[key]; !ok || value != nil {
+			t.Errorf("Expected %v to be null but got %v", key, value)
+		}
+	}
+	if prayers, ok := fields["prayers"].([]any); !ok || len(prayers) != 0 {
+		t.Errorf("Expected empty prayers but got %v", fields["prayers"])
+	}
+}
+
+func TestRenderActivePrayerTrackingJSON(t *testing.T) {
+	output := captureJSON(t, func() error {
+		return RenderActivePrayerTrackingJSON(testTracking(time.Hour+12*time.Minute+30*time.Second), OutputJSON)
+	})
+	assertSchemaVersion(t, output)
+
+	var document trackingDocument
+	if err := json.Unmarshal(output, &document); err != nil {
+		t.Fatal(err)
+	}
+	expected := trackingDocument{
+		SchemaVersion: JSONSchemaVersion,
+		trackingJSON: trackingJSON{
+			scheduleJSON:     expectedScheduleJSON(),
+			Next:             prayerJSON{Name: "Asr", Time: "2026-03-20T15:29:00Z"},
+			Previous:         prayerJSON{Name: "Dhuhr", Time: "2026-03-20T12:01:00Z"},
+			RemainingSeconds: 4350,
+			Progress:         50,
+		},
+	}
+	if !reflect.DeepEqual(document, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, document)
+	}
+	assertRFC3339(t, document.scheduleJSON)
+	assertRFC3339(t, scheduleJSON{Prayers: []prayerJSON{document.Next, document.Previous}})
+}
+
+func TestRenderSchedulesJSON(t *testing.T) {
+	first := testTracking(0).DailyPrayerSchedule
+	second := domain.DailyPrayerSchedule{
+		Date:    time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC),
+		Prayers: []domain.Prayer{{Name: "Fajr", Time: time.Date(2026, 3, 21, 5, 0, 0, 0, time.UTC)}},
+	}
+	expectedSecond := scheduleJSON{
+		Date:    "2026-03-21",
+		Weekday: "Saturday",
+		Prayers: []prayerJSON{{Name: "Fajr", Time: "2026-03-21T05:00:00Z"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		output := captureJSON(t, func() error {
+			return RenderSchedulesJSON([]domain.DailyPrayerSchedule{first, second}, OutputJSON)
+		})
+		assertSchemaVersion(t, output)
+
+		var document schedulesDocument
+		if err := json.Unmarshal(output, &document); err != nil {
+			t.Fatal(err)
+		}
+		expected := schedulesDocument{
+			SchemaVersion: JSONSchemaVersion,
+			Schedules:     []scheduleJSON{expectedScheduleJSON(), expectedSecond},
+		}
+		if !reflect.DeepEqual(document, expected) {
+			t.Errorf("Expected %+v but got %+v", expected, document)
+		}
+	})
+
+	t.Run("json without schedules", func(t *testing.T) {
+		output := captureJSON(t, func() error {
+			return RenderSchedulesJSON(nil, OutputJSON)
+		})
+		var fields map[string]any
+		if err := json.Unmarshal(output, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if schedules, ok := fields["schedules"].([]any); !ok || len(schedules) != 0 {
+			t.Errorf("Expected empty schedules but got %v", fields["schedules"])
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		output := captureJSON(t, func() error {
+			return RenderSchedulesJSON([]domain.DailyPrayerSchedule{first, second}, OutputNDJSON)
+		})
+		lines := ndjsonLines(t, output)
+		expected := []scheduleDocument{
+			{SchemaVersion: JSONSchemaVersion, scheduleJSON: expectedScheduleJSON()},
+			{SchemaVersion: JSONSchemaVersion, scheduleJSON: expectedSecond},
+		}
+		if len(lines) != len(expected) {
+			t.Fatalf("Expected %v lines but got %v", len(expected), len(lines))
+		}
+		for i, line := range lines {
+			assertSchemaVersion(t, line)
+			var document scheduleDocument
+			if err := json.Unmarshal(line, &document); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(document, expected[i]) {
+				t.Errorf("Expected %+v but got %+v", expected[i], document)
+			}
+		}
+	})
+}
+
+func TestRenderEventsJSON(t *testing.T) {
+	today := time.Date(2026, 3, 18, 14, 0, 0, 0, time.UTC)
+	events := []domain.DayEvent{
+		{
+			Date:  time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+			Hijri: domain.HijriDate{Year: 1447, Month: 10, Day: 1},
+			Event: domain.Event{En: "Eid al-Fitr", Ar: "عيد الفطر"},
+		},
+		{
+			Date:  time.Date(2026, 5, 27, 0, 0, 0, 0, time.UTC),
+			Event: domain.Event{En: "Eid al-Adha", Ar: "عيد الأضحى"},
+		},
+	}
+	expected := []dayEventJSON{
+		{
+			Date:     "2026-03-20",
+			Hijri:    expectedScheduleJSON().Hijri,
+			Event:    eventJSON{En: "Eid al-Fitr", Ar: "عيد الفطر"},
+			DaysLeft: 2,
+		},
+		{
+			Date:     "2026-05-27",
+			Event:    eventJSON{En: "Eid al-Adha", Ar: "عيد الأضحى"},
+			DaysLeft: 70,
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		output := captureJSON(t, func() error {
+			return RenderEventsJSON(events, today, OutputJSON)
+		})
+		assertSchemaVersion(t, output)
+
+		var document dayEventsDocument
+		if err := json.Unmarshal(output, &document); err != nil {
+			t.Fatal(err)
+		}
+		if expected := (dayEventsDocument{SchemaVersion: JSONSchemaVersion, Events: expected}); !reflect.DeepEqual(document, expected) {
+			t.Errorf("Expected %+v but got %+v", expected, document)
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		output := captureJSON(t, func() error {
+			return RenderEventsJSON(events, today, OutputNDJSON)
+		})
+		lines := ndjsonLines(t, output)
+		if len(lines) != len(expected) {
+			t.Fatalf("Expected %v lines but got %v", len(expected), len(lines))
+		}
+		for i, line := range lines {
+			assertSchemaVersion(t, line)
+			var document dayEventDocument
+			if err := json.Unmarshal(line, &document); err != nil {
+				t.Fatal(err)
+			}
+			if expected := (dayEventDocument{SchemaVersion: JSONSchemaVersion, dayEventJSON: expected[i]}); !reflect.DeepEqual(document, expected) {
+				t.Errorf("Expected %+v but got %+v", expected, document)
+			}
+		}
+	})
+}
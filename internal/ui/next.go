@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// DefaultNextPrayerFormat is used by @RenderNextPrayer when no format is given
+const DefaultNextPrayerFormat = "{{.Name}} {{.Time}} {{.Remaining}}"
+
+// NextPrayer is what next prayer formats are executed against. Besides its own
+// short fields, all of @domain.ActivePrayerTracking fields are available
+type NextPrayer struct {
+	domain.ActivePrayerTracking
+	Name      string
+	Time      string
+	Remaining string
+}
+
+// RenderNextPrayer prints only next prayer name, time and time remaining using
+// @format template, e.g. "{{.Name}} {{.Remaining}}". Remaining time is printed
+// in seconds if @seconds is true, otherwise like "1h12m". Output has no colors
+// since it is meant to be read by scripts
+func RenderNextPrayer(activePrayerTracking domain.ActivePrayerTracking, format string, seconds bool) error {
+	result, err := FormatNextPrayer(activePrayerTracking, format, seconds)
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}
+
+// FormatNextPrayer returns what @RenderNextPrayer prints, without new line
+func FormatNextPrayer(activePrayerTracking domain.ActivePrayerTracking, format string, seconds bool) (string, error) {
+	if format == "" {
+		format = DefaultNextPrayerFormat
+	}
+	return ExecuteTemplate(format, newNextPrayer(activePrayerTracking, seconds))
+}
+
+func newNextPrayer(activePrayerTracking domain.ActivePrayerTracking, seconds bool) NextPrayer {
+	remaining := FormatDurationShort(activePrayerTracking.TimeRemaining)
+	if seconds {
+		remaining = fmt.Sprint(int(activePrayerTracking.TimeRemaining.Seconds()))
+	}
+
+	return NextPrayer{
+		ActivePrayerTracking: activePrayerTracking,
+		Name:                 activePrayerTracking.NextPrayer,
+		Time:                 activePrayerTracking.NextPrayerTime.Format(TimeLayout),
+		Remaining:            remaining,
+	}
+}
+
+// FormatDurationShort formats @duration like "1h12m" or "12m", rounding seconds down
+func FormatDurationShort(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%vh%vm", hours, minutes)
+	}
+	return fmt.Sprintf("%vm", minutes)
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// TemplateFuncs are helpers available to user templates:
+//
+//	fmtTime "15:04" .Time     format time with Go layout
+//	until .NextPrayerTime     time left to given time, like "1h12m"
+//	duration .TimeRemaining   format duration like "1h12m"
+//	hijri .Hijri              hijri date in --hijri-lang, like "27 Ramadan 1447 AH"
+//	arabic .NextPrayer        arabic prayer name, event name, hijri date, time, date or number
+//	pad 8 .Name               pad to 8 characters, negative width pads on the left
+var TemplateFuncs = template.FuncMap{
+	"fmtTime": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"until": func(t time.Time) string {
+		return FormatDurationShort(time.Until(t))
+	},
+	"duration": FormatDurationShort,
+	"hijri": func(hijri domain.HijriDate) string {
+		return hijri.Format(HijriLanguage)
+	},
+	"arabic": toArabic,
+	"pad":    pad,
+}
+
+// RenderTemplate prints @data executed with @text template. @data is usually
+// @domain.DailyPrayerSchedule or @domain.ActivePrayerTracking
+func RenderTemplate(text string, data any) error {
+	result, err := ExecuteTemplate(text, data)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, result)
+	return nil
+}
+
+// ExecuteTemplate returns @data executed with @text template, with @TemplateFuncs
+func ExecuteTemplate(text string, data any) (string, error) {
+	tmpl, err := template.New("format").Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// arabicDigits replaces western digits with arabic-indic ones
+var arabicDigits = strings.NewReplacer(
+	"0", "٠", "1", "١", "2", "٢", "3", "٣", "4", "٤",
+	"5", "٥", "6", "٦", "7", "٧", "8", "٨", "9", "٩",
+)
+
+// toArabic converts @v to arabic: hijri dates and events use their arabic
+// forms, prayer names are translated, times use arabic digits and meridiem,
+// dates at midnight are formatted as dates and numbers use arabic digits.
+// Other strings are returned unchanged
+func toArabic(v any) string {
+	switch value := v.(type) {
+	case domain.HijriDate:
+		return arabicDigits.Replace(value.Format(domain.HijriLanguageAr))
+	case domain.Event:
+		return value.Name(domain.HijriLanguageAr)
+	case time.Time:
+		if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 {
+			return arabicDigits.Replace(value.Format(time.DateOnly))
+		}
+		meridiem := "ص"
+		if value.Hour() >= 12 {
+			meridiem = "م"
+		}
+		return arabicDigits.Replace(value.Format("3:04")) + " " + meridiem
+	case string:
+		if name, ok := models.ArabicPrayerNames[value]; ok {
+			return name
+		}
+		return value
+	case int, int64, float64:
+		return arabicDigits.Replace(fmt.Sprint(value))
+	}
+	return fmt.Sprint(v)
+}
+
+// pad pads @v with spaces to @width characters, on the right or, if @width is
+// negative, on the left
+func pad(width int, v any) string {
+	s := fmt.Sprint(v)
+	left := width < 0
+	if left {
+		width = -width
+	}
+
+	padding := width - utf8.RuneCountInString(s)
+	if padding <= 0 {
+		return s
+	}
+	if left {
+		return strings.Repeat(" ", padding) + s
+	}
+	return s + strings.Repeat(" ", padding)
+}
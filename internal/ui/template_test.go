@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+func TestExecuteTemplateFuncs(t *testing.T) {
+	tracking := testTracking(90 * time.Minute)
+	ramadan := domain.HijriDate{Year: 1447, Month: 9, Day: 27}
+
+	tests := []struct {
+		name     string
+		text     string
+		data     any
+		expected string
+	}{
+		{name: "fmtTime", text: `{{fmtTime "15:04" .NextPrayerTime}}`, data: tracking, expected: "15:29"},
+		{name: "fmtTime date", text: `{{fmtTime "2006-01-02" .Date}}`, data: tracking, expected: "2026-03-20"},
+		{name: "until", text: `{{until .}}`, data: time.Now().Add(72*time.Minute + 30*time.Second), expected: "1h12m"},
+		{name: "until under an hour", text: `{{until .}}`, data: time.Now().Add(5*time.Minute + 30*time.Second), expected: "5m"},
+		{name: "duration", text: `{{duration .TimeRemaining}}`, data: tracking, expected: "1h30m"},
+		{name: "hijri", text: `{{hijri .}}`, data: ramadan, expected: "27 Ramadan 1447 AH"},
+		{name: "arabic prayer name", text: `{{arabic .NextPrayer}}`, data: tracking, expected: "العصر"},
+		{name: "arabic other string", text: `{{arabic .}}`, data: "Ramadan in 2 days, Asrama", expected: "Ramadan in 2 days, Asrama"},
+		{name: "arabic afternoon time", text: `{{arabic .NextPrayerTime}}`, data: tracking, expected: "٣:٢٩ م"},
+		{name: "arabic morning time", text: `{{arabic (index .Prayers 0).Time}}`, data: tracking, expected: "٥:٠١ ص"},
+		{name: "arabic date", text: `{{arabic .Date}}`, data: tracking, expected: "٢٠٢٦-٠٣-٢٠"},
+		{name: "arabic hijri", text: `{{arabic .}}`, data: ramadan, expected: "٢٧ رمضان ١٤٤٧ هـ"},
+		{name: "arabic event", text: `{{arabic .Event}}`, data: tracking, expected: "عيد الفطر"},
+		{name: "arabic number", text: `{{arabic .Progress}}`, data: tracking, expected: "٥٠"},
+		{name: "pad right", text: `[{{pad 6 .NextPrayer}}]`, data: tracking, expected: "[Asr   ]"},
+		{name: "pad left", text: `[{{pad -6 .NextPrayer}}]`, data: tracking, expected: "[   Asr]"},
+		{name: "pad shorter width", text: `[{{pad 2 .NextPrayer}}]`, data: tracking, expected: "[Asr]"},
+		{name: "pad arabic", text: `[{{pad 7 (arabic .NextPrayer)}}]`, data: tracking, expected: "[العصر  ]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExecuteTemplate(tt.text, tt.data)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
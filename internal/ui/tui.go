@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// hijriSearchMaxYear tells hijri dates from gregorian ones in search, e.g.
+// 1447-09-27 is hijri while 2026-03-20 is gregorian
+const hijriSearchMaxYear = 1600
+
+var (
+	panelStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	headingStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	nextRowStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("10"))
+	fridayStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	eventStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	mutedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	prayedSymbol  = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓")
+	tuiHelpKeys   = "h/l day  j/k week  t today  / search  1-5 log prayer  q quit"
+	tuiSearchHint = "date like 2026-03-20, 20/03, next friday, or hijri like 1447-09-27"
+)
+
+type tickMsg time.Time
+
+// TUI is an interactive full screen view of prayer times of a day, opening on
+// today. It is a bubbletea model, run it with @RunTUI
+type TUI struct {
+	repo      domain.PrayerTimesRepo
+	prayerLog *domain.PrayerLog
+	now       func() time.Time
+
+	// date is the selected day, at midnight
+	date     time.Time
+	schedule domain.DailyPrayerSchedule
+	// tracking is set only while today is selected
+	tracking *domain.ActivePrayerTracking
+	night    *domain.NightMarkers
+
+	searching bool
+	query     string
+	err       error
+
+	width int
+}
+
+// NewTUI creates TUI on today. Prayer log is not shown if @prayerLog is nil
+func NewTUI(repo domain.PrayerTimesRepo, prayerLog *domain.PrayerLog, now func() time.Time) *TUI {
+	m := &TUI{repo: repo, prayerLog: prayerLog, now: now, width: 80}
+	m.load(now())
+	return m
+}
+
+// RunTUI runs @m full screen until user quits
+func RunTUI(m *TUI) error {
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m *TUI) Init() tea.Cmd {
+	return tick()
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (m *TUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case tickMsg:
+		m.refreshTracking()
+		return m, tick()
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+		return m, m.updateKey(msg)
+	}
+	return m, nil
+}
+
+func (m *TUI) updateKey(msg tea.KeyMsg) tea.Cmd {
+	m.err = nil
+
+	switch key := msg.String(); key {
+	case "q", "ctrl+c", "esc":
+		return tea.Quit
+	case "left", "h":
+		m.load(m.date.AddDate(0, 0, -1))
+	case "right", "l":
+		m.load(m.date.AddDate(0, 0, 1))
+	case "up", "k":
+		m.load(m.date.AddDate(0, 0, -7))
+	case "down", "j":
+		m.load(m.date.AddDate(0, 0, 7))
+	case "t":
+		m.load(m.now())
+	case "/":
+		m.searching = true
+		m.query = ""
+	case "1", "2", "3", "4", "5":
+		m.togglePrayed(models.SortedPrayerNames[key[0]-'1'])
+	}
+	return nil
+}
+
+func (m *TUI) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEsc:
+		m.searching = false
+	case tea.KeyEnter:
+		m.searching = false
+		date, err := m.findDate(m.query)
+		if err != nil {
+			m.err = err
+			return nil
+		}
+		m.load(date)
+	case tea.KeyBackspace:
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+	}
+	return nil
+}
+
+// findDate parses @query as hijri date if its year is before
+// @hijriSearchMaxYear, otherwise as gregorian date argument
+func (m *TUI) findDate(query string) (time.Time, error) {
+	if hijri, err := domain.ParseHijriDate(strings.TrimSpace(query)); err == nil && hijri.Year < hijriSearchMaxYear {
+		return m.repo.FindHijriDate(hijri)
+	}
+	return domain.ParseDate(query, m.now())
+}
+
+// load selects day of @date, keeping previous day if it has no data
+func (m *TUI) load(date time.Time) {
+	now := m.now()
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+
+	schedule, err := m.repo.GetDailyPrayerSchedule(date)
+	if err != nil {
+		m.err = fmt.Errorf("no prayer times of %v", date.Format("02/01/2006"))
+		return
+	}
+	m.date = date
+	m.schedule = schedule
+	m.tracking = nil
+	m.night = nil
+
+	if domain.SameDay(date, now) {
+		if tracking, err := m.repo.GetActivePrayerTracking(now); err == nil {
+			m.tracking = &tracking
+		}
+	}
+	if tomorrow, err := m.repo.GetDailyPrayerSchedule(date.AddDate(0, 0, 1)); err == nil {
+		if night, ok := domain.GetNightMarkers(schedule, tomorrow); ok {
+			m.night = &night
+		}
+	}
+}
+
+// refreshTracking moves countdown forward, fetching tracking again when next
+// prayer comes, and moving to new day at midnight if today was selected
+func (m *TUI) refreshTracking() {
+	if m.tracking == nil {
+		return
+	}
+	now := m.now()
+	if !domain.SameDay(now, m.date) {
+		m.load(now)
+		return
+	}
+	if !now.Before(m.tracking.NextPrayerTime) {
+		if tracking, err := m.repo.GetActivePrayerTracking(now); err == nil {
+			m.tracking = &tracking
+		}
+		return
+	}
+	tracking := m.tracking.At(now)
+	m.tracking = &tracking
+}
+
+// togglePrayed logs prayer named @name of selected day, only once its time came
+func (m *TUI) togglePrayed(name string) {
+	if m.prayerLog == nil {
+		return
+	}
+	prayerTime, ok := domain.FindPrayer(m.schedule.Prayers, name)
+	if !ok {
+		return
+	}
+	if prayerTime.After(m.now()) {
+		m.err = fmt.Errorf("%v time has not come yet", name)
+		return
+	}
+	if err := m.prayerLog.Toggle(m.date, name); err != nil {
+		m.err = err
+	}
+}
+
+func (m *TUI) View() string {
+	day := m.renderDay()
+	side := m.renderSide()
+
+	var body string
+	if m.width >= lipgloss.Width(day)+lipgloss.Width(side) {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, day, side)
+	} else {
+		body = lipgloss.JoinVertical(lipgloss.Left, day, side)
+	}
+
+	footer := mutedStyle.Render(tuiHelpKeys)
+	switch {
+	case m.searching:
+		footer = fmt.Sprintf("/%v█\n%v", m.query, mutedStyle.Render(tuiSearchHint))
+	case m.err != nil:
+		footer = errorStyle.Render(m.err.Error()) + "\n" + footer
+	}
+	return body + "\n" + footer
+}
+
+func (m *TUI) renderDay() string {
+	title := m.date.Format("Monday 02/01/2006")
+	if m.date.Weekday() == time.Friday {
+		title = fridayStyle.Render(title)
+	}
+	if domain.SameDay(m.date, m.now()) {
+		title += mutedStyle.Render("  today")
+	}
+	lines := []string{headingStyle.Render(title), ""}
+
+	row := func(name string, t time.Time, prayed bool, next bool) {
+		mark := " "
+		if prayed {
+			mark = prayedSymbol
+		}
+		line := fmt.Sprintf("%-8v %8v", name, t.Format(TimeLayout))
+		if next {
+			line = nextRowStyle.Render(line)
+		}
+		lines = append(lines, fmt.Sprintf("%v %v", line, mark))
+	}
+	for _, p := range m.schedule.Prayers {
+		next := m.tracking != nil && p.Name == m.tracking.NextPrayer && p.Time.Equal(m.tracking.NextPrayerTime)
+		row(p.Name, p.Time, m.prayerLog != nil && m.prayerLog.Prayed(m.date, p.Name), next)
+		if p.Name == models.SortedPrayerNames[0] && !m.schedule.Sunrise.IsZero() {
+			row("Sunrise", m.schedule.Sunrise, false, false)
+		}
+	}
+
+	if m.tracking != nil {
+		lines = append(lines,
+			"",
+			formatTimeRemaining(m.tracking.NextPrayer, m.tracking.TimeRemaining, true),
+			formatTimeProgress(m.tracking.PreviousPrayer, m.tracking.NextPrayer, m.tracking.Progress, 24),
+		)
+	}
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+func (m *TUI) renderSide() string {
+	hijri := m.schedule.Hijri
+	if m.tracking != nil {
+		// may have moved to next day after maghrib
+		hijri = m.tracking.Hijri
+	}
+
+	lines := []string{headingStyle.Render("Hijri"), orNone(hijri.Format(HijriLanguage)), ""}
+	lines = append(lines, headingStyle.Render("Event"))
+	if m.schedule.Event.IsZero() {
+		lines = append(lines, mutedStyle.Render("none"))
+	} else {
+		lines = append(lines, eventStyle.Render(m.schedule.Event.Name(HijriLanguage)))
+	}
+
+	lines = append(lines, "", headingStyle.Render("Night"))
+	if m.night == nil {
+		lines = append(lines, mutedStyle.Render("unknown"))
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("%-11v %8v", "Maghrib", m.night.Maghrib.Format(TimeLayout)),
+			fmt.Sprintf("%-11v %8v", "Midnight", m.night.Midnight.Format(TimeLayout)),
+			fmt.Sprintf("%-11v %8v", "Last third", m.night.LastThird.Format(TimeLayout)),
+			fmt.Sprintf("%-11v %8v", "Fajr", m.night.Fajr.Format(TimeLayout)),
+		)
+	}
+
+	if m.prayerLog != nil {
+		lines = append(lines, "", headingStyle.Render("Prayer log"))
+		lines = append(lines, fmt.Sprintf("%v/%v prayed", m.prayerLog.Count(m.date), len(models.SortedPrayerNames)))
+		weekStart := m.date.AddDate(0, 0, -6)
+		weekCount := 0
+		for d := weekStart; !d.After(m.date); d = d.AddDate(0, 0, 1) {
+			weekCount += m.prayerLog.Count(d)
+		}
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("%v/%v in last 7 days", weekCount, 7*len(models.SortedPrayerNames))))
+	}
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return mutedStyle.Render("none")
+	}
+	return s
+}
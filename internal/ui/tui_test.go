@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+	"github.com/mabd-dev/prayer-times-cli/internal/models"
+)
+
+// tuiTestRepo has prayer times of march 2026 only, other methods are not used by TUI
+type tuiTestRepo struct {
+	domain.PrayerTimesRepo
+}
+
+func (tuiTestRepo) GetDailyPrayerSchedule(date time.Time) (domain.DailyPrayerSchedule, error) {
+	if date.Year() != 2026 || date.Month() != time.March {
+		return domain.DailyPrayerSchedule{}, errors.New("no data")
+	}
+	at := func(hour int) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, date.Location())
+	}
+	return domain.DailyPrayerSchedule{
+		Date:  date,
+		Hijri: domain.HijriDate{Year: 1447, Month: 9, Day: date.Day() + 10},
+		Prayers: []domain.Prayer{
+			{Name: "Fajr", Time: at(5)},
+			{Name: "Dhuhr", Time: at(12)},
+			{Name: "Asr", Time: at(15)},
+			{Name: "Maghrib", Time: at(18)},
+			{Name: "Isha", Time: at(19)},
+		},
+	}, nil
+}
+
+func (r tuiTestRepo) GetActivePrayerTracking(date time.Time) (domain.ActivePrayerTracking, error) {
+	schedule, err := r.GetDailyPrayerSchedule(date)
+	if err != nil {
+		return domain.ActivePrayerTracking{}, err
+	}
+	return domain.ActivePrayerTracking{
+		DailyPrayerSchedule: schedule,
+		PreviousPrayer:      "Dhuhr",
+		NextPrayer:          "Asr",
+		NextPrayerTime:      schedule.Prayers[2].Time,
+		TimeRemaining:       schedule.Prayers[2].Time.Sub(date),
+	}, nil
+}
+
+func (tuiTestRepo) FindHijriDate(hijri domain.HijriDate) (time.Time, error) {
+	if hijri.Year != 1447 || hijri.Month != 9 {
+		return time.Time{}, errors.New("not found")
+	}
+	return time.Date(2026, 3, hijri.Day-10, 0, 0, 0, 0, time.UTC), nil
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestTUIKeys(t *testing.T) {
+	now := time.Date(2026, 3, 20, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		keys          []string
+		expectedDate  time.Time
+		expectedError bool
+	}{
+		{name: "Opens on today", expectedDate: now},
+		{name: "Next day", keys: []string{"l"}, expectedDate: now.AddDate(0, 0, 1)},
+		{name: "Previous day", keys: []string{"left"}, expectedDate: now.AddDate(0, 0, -1)},
+		{name: "Next week", keys: []string{"j"}, expectedDate: now.AddDate(0, 0, 7)},
+		{name: "Back to today", keys: []string{"k", "k", "t"}, expectedDate: now},
+		{name: "Out of data keeps day", keys: []string{"k", "k", "k"}, expectedDate: now.AddDate(0, 0, -14), expectedError: true},
+		{name: "Search gregorian date", keys: []string{"/", "2026-03-05", "enter"}, expectedDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "Search hijri date", keys: []string{"/", "1447-09-27", "enter"}, expectedDate: time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "Search invalid date", keys: []string{"/", "soon", "enter"}, expectedDate: now, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTUI(tuiTestRepo{}, nil, func() time.Time { return now })
+			for _, key := range tt.keys {
+				m.Update(keyMsg(key))
+			}
+
+			if !domain.SameDay(m.date, tt.expectedDate) {
+				t.Errorf("Expected %v to be selected but got %v", tt.expectedDate, m.date)
+			}
+			if hasError := m.err != nil; hasError != tt.expectedError {
+				t.Errorf("Expected error %v but got %v", tt.expectedError, m.err)
+			}
+			if isToday := m.tracking != nil; isToday != domain.SameDay(m.date, now) {
+				t.Errorf("Expected tracking only while today is selected")
+			}
+		})
+	}
+}
+
+type tuiTestPrayerLogStorage struct{}
+
+func (tuiTestPrayerLogStorage) SavePrayerLog(models.PrayerLogDto) error  { return nil }
+func (tuiTestPrayerLogStorage) LoadPrayerLog(*models.PrayerLogDto) error { return nil }
+
+func TestTUILogPrayer(t *testing.T) {
+	now := time.Date(2026, 3, 20, 13, 0, 0, 0, time.UTC)
+	prayerLog, err := domain.LoadPrayerLog(tuiTestPrayerLogStorage{})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	m := NewTUI(tuiTestRepo{}, prayerLog, func() time.Time { return now })
+
+	m.Update(keyMsg("2"))
+	if !prayerLog.Prayed(now, "Dhuhr") || m.err != nil {
+		t.Errorf("Expected dhuhr to be logged but got error %v", m.err)
+	}
+
+	m.Update(keyMsg("3"))
+	if prayerLog.Prayed(now, "Asr") || m.err == nil {
+		t.Errorf("Expected asr not to be logged before its time")
+	}
+
+	m.Update(keyMsg("h"))
+	m.Update(keyMsg("5"))
+	if !prayerLog.Prayed(now.AddDate(0, 0, -1), "Isha") {
+		t.Errorf("Expected isha of yesterday to be logged")
+	}
+	if view := m.View(); !strings.Contains(view, "1/35 in last 7 days") {
+		t.Errorf("Expected view to count prayers of last 7 days, got %q", view)
+	}
+}
+
+func TestTUIQuit(t *testing.T) {
+	m := NewTUI(tuiTestRepo{}, nil, func() time.Time { return time.Date(2026, 3, 20, 13, 0, 0, 0, time.UTC) })
+	if _, cmd := m.Update(keyMsg("q")); cmd == nil {
+		t.Fatalf("Expected q to quit")
+	}
+
+	m.Update(keyMsg("/"))
+	if _, cmd := m.Update(keyMsg("q")); cmd != nil || m.query != "q" {
+		t.Errorf("Expected q to be typed while searching but got query %q", m.query)
+	}
+}
+
+func TestTUIView(t *testing.T) {
+	m := NewTUI(tuiTestRepo{}, nil, func() time.Time { return time.Date(2026, 3, 20, 13, 0, 0, 0, time.UTC) })
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	view := m.View()
+
+	for _, expected := range []string{"Friday 20/03/2026", "Ramadan", "Midnight", "Last third", "seconds to Asr"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected view to contain %q, got %q", expected, view)
+		}
+	}
+	if strings.Contains(view, "Prayer log") {
+		t.Errorf("Expected no prayer log section without log")
+	}
+}
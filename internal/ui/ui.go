@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,15 +18,29 @@ var (
 	remainingTimeFgColor     = color.New(color.FgHiGreen)
 	timeProgressFgColor      = color.New(color.FgHiGreen)
 	timeProgressBgColor      = color.New(color.BgHiGreen)
+	hijriDateFgColor         = color.New(color.FgHiBlack)
+	eventFgColor             = color.New(color.FgHiYellow)
+	todayFgColor             = color.New(color.FgHiGreen, color.Bold)
+	nextPrayerColor          = color.New(color.FgBlack, color.BgHiGreen)
+	fridayFgColor            = color.New(color.FgHiCyan)
 )
 
+// TimeLayout is how prayer times are formatted, shared with exports so they
+// look the same as table output
+const TimeLayout = "3:04 pm"
+
+// HijriLanguage is the language hijri month names and events are rendered with
+var HijriLanguage = domain.HijriLanguageEn
+
 func RenderDailyPrayerSchedule(dailyPrayerSchedule domain.DailyPrayerSchedule) {
-	RenderDate(dailyPrayerSchedule.Date)
+	RenderDate(dailyPrayerSchedule.Date, dailyPrayerSchedule.Hijri)
+	RenderEvent(dailyPrayerSchedule.Event)
 	RenderPrayerTimes(dailyPrayerSchedule.Prayers)
 }
 
 func RenderActivePrayerTracking(activePrayerTracking domain.ActivePrayerTracking) {
-	RenderDate(activePrayerTracking.Date)
+	RenderDate(activePrayerTracking.Date, activePrayerTracking.Hijri)
+	RenderEvent(activePrayerTracking.Event)
 	RenderPrayerTimes(activePrayerTracking.Prayers)
 	RenderTimeRemaining(activePrayerTracking.NextPrayer, activePrayerTracking.TimeRemaining)
 	RenderTimeProgress(
@@ -35,14 +51,18 @@ func RenderActivePrayerTracking(activePrayerTracking domain.ActivePrayerTracking
 }
 
 func RenderPrayerTimes(prayers []domain.Prayer) {
-	table := table.New(os.Stdout)
+	writePrayerTimes(os.Stdout, prayers)
+}
+
+func writePrayerTimes(w io.Writer, prayers []domain.Prayer) {
+	table := table.New(w)
 
 	headers := []string{}
 	prayerTimes := []string{}
 	for _, p := range prayers {
 		headers = append(headers, prayerTimeHeaderrFgColor.Sprint(p.Name))
 
-		timeFormatted := p.Time.Format("3:04 pm")
+		timeFormatted := p.Time.Format(TimeLayout)
 		prayerTimes = append(prayerTimes, timeFormatted)
 	}
 	table.SetHeaders(headers...)
@@ -50,10 +70,178 @@ func RenderPrayerTimes(prayers []domain.Prayer) {
 	table.Render()
 }
 
-// RenderDate format date and draw it on screen
-func RenderDate(time time.Time) {
+// RenderWeek draw schedules as a table with a row for each day, highlighting
+// today and next prayer relative to @now
+func RenderWeek(schedules []domain.DailyPrayerSchedule, now time.Time) {
+	table := table.New(os.Stdout)
+
+	headers := []string{prayerTimeHeaderrFgColor.Sprint("Day")}
+	if len(schedules) > 0 {
+		for _, p := range schedules[0].Prayers {
+			headers = append(headers, prayerTimeHeaderrFgColor.Sprint(p.Name))
+		}
+	}
+	table.SetHeaders(headers...)
+
+	nextPrayer := weekNextPrayer(schedules, now)
+	for _, s := range schedules {
+		day := s.Date.Format("Mon 02/01")
+		if domain.SameDay(s.Date, now) {
+			day = todayFgColor.Sprint(day)
+		}
+
+		row := []string{day}
+		for _, p := range s.Prayers {
+			timeFormatted := p.Time.Format(TimeLayout)
+			if !nextPrayer.IsZero() && p.Time.Equal(nextPrayer) {
+				timeFormatted = nextPrayerColor.Sprint(timeFormatted)
+			}
+			row = append(row, timeFormatted)
+		}
+		table.AddRow(row...)
+	}
+	table.Render()
+}
+
+// weekNextPrayer returns time of first prayer at or after @now in
+// @schedules, or zero time if @now is not in one of their days
+func weekNextPrayer(schedules []domain.DailyPrayerSchedule, now time.Time) time.Time {
+	if !slices.ContainsFunc(schedules, func(s domain.DailyPrayerSchedule) bool { return domain.SameDay(s.Date, now) }) {
+		return time.Time{}
+	}
+	for _, s := range schedules {
+		for _, p := range s.Prayers {
+			if !p.Time.Before(now) {
+				return p.Time
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// RenderMonth draw month schedules as a timetable, titled with gregorian and
+// hijri months it spans
+func RenderMonth(schedules []domain.DailyPrayerSchedule, now time.Time) {
+	if len(schedules) == 0 {
+		return
+	}
+
+	first := schedules[0]
+	last := schedules[len(schedules)-1]
+	hijriMonths := fmt.Sprintf("%v %v", first.Hijri.MonthName(HijriLanguage), first.Hijri.Year)
+	if first.Hijri.Month != last.Hijri.Month {
+		hijriMonths = fmt.Sprintf("%v - %v %v", hijriMonths, last.Hijri.MonthName(HijriLanguage), last.Hijri.Year)
+	}
+	fmt.Printf("%v  %v\n", first.Date.Format("January 2006"), hijriDateFgColor.Sprint(hijriMonths))
+	RenderTimetable(schedules, now)
+}
+
+// RenderTimetable draw schedules as a timetable that fits in 80 columns terminal,
+// with hijri day of each day, fridays highlighted and events listed below it
+func RenderTimetable(schedules []domain.DailyPrayerSchedule, now time.Time) {
+	if len(schedules) == 0 {
+		return
+	}
+	first := schedules[0]
+
+	table := table.New(os.Stdout)
+	table.SetRowLines(false)
+
+	headers := []string{prayerTimeHeaderrFgColor.Sprint("Day"), prayerTimeHeaderrFgColor.Sprint("Hijri")}
+	for i, p := range first.Prayers {
+		headers = append(headers, prayerTimeHeaderrFgColor.Sprint(p.Name))
+		if i == 0 {
+			headers = append(headers, prayerTimeHeaderrFgColor.Sprint("Sunrise"))
+		}
+	}
+	table.SetHeaders(headers...)
+
+	events := []domain.DailyPrayerSchedule{}
+	for _, s := range schedules {
+		day := s.Date.Format("Mon 02/01")
+		if !s.Event.IsZero() {
+			day += " *"
+			events = append(events, s)
+		}
+
+		dayColor := color.New()
+		if domain.SameDay(s.Date, now) {
+			dayColor = todayFgColor
+		} else if s.Date.Weekday() == time.Friday {
+			dayColor = fridayFgColor
+		}
+
+		row := []string{dayColor.Sprint(day), fmt.Sprint(s.Hijri.Day)}
+		for i, p := range s.Prayers {
+			row = append(row, p.Time.Format("15:04"))
+			if i == 0 {
+				row = append(row, formatOptionalTime(s.Sunrise, "15:04"))
+			}
+		}
+		table.AddRow(row...)
+	}
+	table.Render()
+
+	for _, e := range events {
+		fmt.Printf("* %v  %v\n", e.Date.Format("Mon 02/01"), eventFgColor.Sprint(e.Event.Name(HijriLanguage)))
+	}
+}
+
+// formatOptionalTime formats @t with @layout, or "-" if it is zero
+func formatOptionalTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(layout)
+}
+
+// RenderDate format gregorian and hijri dates and draw them on screen
+func RenderDate(time time.Time, hijri domain.HijriDate) {
+	fmt.Println(formatDate(time, hijri))
+}
+
+func formatDate(time time.Time, hijri domain.HijriDate) string {
 	formatted := time.Format("Monday 02/01/2006")
-	fmt.Println(formatted)
+	if !hijri.IsZero() {
+		formatted = fmt.Sprintf("%v  %v", formatted, hijriDateFgColor.Sprint(hijri.Format(HijriLanguage)))
+	}
+	return formatted
+}
+
+// RenderEvent draw event name on screen, if there is one
+func RenderEvent(event domain.Event) {
+	if event.IsZero() {
+		return
+	}
+	fmt.Println(eventFgColor.Sprint(event.Name(HijriLanguage)))
+}
+
+// RenderEvents draw events as a table with days remaining to each one from @today
+func RenderEvents(events []domain.DayEvent, today time.Time) {
+	table := table.New(os.Stdout)
+	table.SetHeaders(
+		prayerTimeHeaderrFgColor.Sprint("Date"),
+		prayerTimeHeaderrFgColor.Sprint("Hijri"),
+		prayerTimeHeaderrFgColor.Sprint("Event"),
+		prayerTimeHeaderrFgColor.Sprint("Days left"),
+	)
+
+	for _, e := range events {
+		daysLeft := "-"
+		switch days := domain.DaysBetween(today, e.Date); {
+		case days == 0:
+			daysLeft = "today"
+		case days > 0:
+			daysLeft = fmt.Sprint(days)
+		}
+		table.AddRow(
+			e.Date.Format("Mon 02/01/2006"),
+			e.Hijri.Format(HijriLanguage),
+			e.Event.Name(HijriLanguage),
+			daysLeft,
+		)
+	}
+	table.Render()
 }
 
 // RenderTimeRemaining show how many hours and minutes remaining till next prayer +
@@ -62,10 +250,19 @@ func RenderTimeRemaining(
 	nextPrayer string,
 	duration time.Duration,
 ) {
+	fmt.Println(formatTimeRemaining(nextPrayer, duration, false))
+}
+
+// formatTimeRemaining formats time remaining line, with seconds if @seconds is true
+func formatTimeRemaining(nextPrayer string, duration time.Duration, seconds bool) string {
 	coloredPrayerName := remainingTimeFgColor.Sprint(nextPrayer)
 	coloredHours := remainingTimeFgColor.Sprint(int(duration.Hours()))
 	coloredMinutes := remainingTimeFgColor.Sprint(int(duration.Minutes()) % 60)
-	fmt.Printf("%v hours, %v minutes to %v\n", coloredHours, coloredMinutes, coloredPrayerName)
+	if seconds {
+		coloredSeconds := remainingTimeFgColor.Sprint(int(duration.Seconds()) % 60)
+		return fmt.Sprintf("%v hours, %v minutes, %v seconds to %v", coloredHours, coloredMinutes, coloredSeconds, coloredPrayerName)
+	}
+	return fmt.Sprintf("%v hours, %v minutes to %v", coloredHours, coloredMinutes, coloredPrayerName)
 }
 
 // RenderTimeProgress shows previous and next prayer names and in between progress bar like
@@ -74,8 +271,17 @@ func RenderTimeProgress(
 	nextPrayer string,
 	timeProgressPercent float64,
 ) {
+	fmt.Println(formatTimeProgress(previousPrayer, nextPrayer, timeProgressPercent, 40))
+}
+
+// formatTimeProgress formats progress bar of @totalNumberOfSymbols between prayer names
+func formatTimeProgress(
+	previousPrayer string,
+	nextPrayer string,
+	timeProgressPercent float64,
+	totalNumberOfSymbols int,
+) string {
 	symbol := "─"
-	totalNumberOfSymbols := 40
 	coloredSymbols := int(timeProgressPercent / 100 * float64(totalNumberOfSymbols))
 	whiteSymbols := totalNumberOfSymbols - coloredSymbols
 
@@ -91,6 +297,5 @@ func RenderTimeProgress(
 	}
 	sb.WriteString(" ")
 	sb.WriteString(nextPrayer)
-	fmt.Println(sb.String())
-
+	return sb.String()
 }
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+func TestWeekNextPrayer(t *testing.T) {
+	at := func(day int, hour int, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	var week []domain.DailyPrayerSchedule
+	for day := 16; day <= 22; day++ {
+		week = append(week, domain.DailyPrayerSchedule{
+			Date:    at(day, 0, 0),
+			Prayers: []domain.Prayer{{Name: "Fajr", Time: at(day, 5, 0)}, {Name: "Isha", Time: at(day, 19, 0)}},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{name: "Later today", now: at(18, 12, 0), expected: at(18, 19, 0)},
+		{name: "Tomorrow", now: at(18, 20, 0), expected: at(19, 5, 0)},
+		{name: "After last prayer of week", now: at(22, 20, 0)},
+		{name: "Week in the future", now: at(10, 12, 0)},
+		{name: "Week in the past", now: at(25, 12, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := weekNextPrayer(week, tt.now); !result.Equal(tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mabd-dev/prayer-times-cli/internal/domain"
+)
+
+// Terminal control sequences used by watch view
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	cursorHome     = "\x1b[H"
+	clearScreen    = "\x1b[2J"
+	clearLine      = "\x1b[K"
+	clearBelow     = "\x1b[J"
+	pushTitle      = "\x1b[22;0t"
+	popTitle       = "\x1b[23;0t"
+)
+
+// EnterWatchScreen switches to alternate screen and hides cursor, saving
+// terminal title if @title is true, so @ExitWatchScreen restores all of them
+func EnterWatchScreen(w io.Writer, title bool) {
+	if title {
+		io.WriteString(w, pushTitle)
+	}
+	io.WriteString(w, enterAltScreen+hideCursor+clearScreen)
+}
+
+// ExitWatchScreen undoes @EnterWatchScreen
+func ExitWatchScreen(w io.Writer, title bool) {
+	io.WriteString(w, showCursor+exitAltScreen)
+	if title {
+		io.WriteString(w, popTitle)
+	}
+}
+
+// ClearWatchScreen clears whole screen, e.g. after resize wrapped old lines
+func ClearWatchScreen(w io.Writer) {
+	io.WriteString(w, clearScreen)
+}
+
+// RenderWatchFrame draws @activePrayerTracking over previous frame in one
+// write, with seconds in countdown and progress bar fitting @width columns.
+// Terminal title is set to next prayer and time remaining if @title is true
+func RenderWatchFrame(w io.Writer, activePrayerTracking domain.ActivePrayerTracking, width int, title bool) error {
+	var sb strings.Builder
+	sb.WriteString(cursorHome)
+	if title {
+		fmt.Fprintf(&sb, "\x1b]0;%v in %v\x07", activePrayerTracking.NextPrayer, FormatDurationShort(activePrayerTracking.TimeRemaining))
+	}
+
+	lines := []string{formatDate(activePrayerTracking.Date, activePrayerTracking.Hijri)}
+	if !activePrayerTracking.Event.IsZero() {
+		lines = append(lines, eventFgColor.Sprint(activePrayerTracking.Event.Name(HijriLanguage)))
+	}
+
+	var prayerTimes strings.Builder
+	writePrayerTimes(&prayerTimes, activePrayerTracking.Prayers)
+	lines = append(lines, strings.Split(strings.TrimSuffix(prayerTimes.String(), "\n"), "\n")...)
+
+	symbols := width - len(activePrayerTracking.PreviousPrayer) - len(activePrayerTracking.NextPrayer) - 2
+	lines = append(lines,
+		formatTimeRemaining(activePrayerTracking.NextPrayer, activePrayerTracking.TimeRemaining, true),
+		formatTimeProgress(activePrayerTracking.PreviousPrayer, activePrayerTracking.NextPrayer, activePrayerTracking.Progress, min(max(symbols, 10), 60)),
+		"",
+		hijriDateFgColor.Sprint("Press Ctrl-C to quit"),
+	)
+
+	for _, line := range lines {
+		sb.WriteString(line + clearLine + "\n")
+	}
+	sb.WriteString(clearBelow)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
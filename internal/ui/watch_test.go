@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderWatchFrame(t *testing.T) {
+	tracking := testTracking(time.Hour + 12*time.Minute + 5*time.Second)
+
+	tests := []struct {
+		name          string
+		title         bool
+		expectedTitle bool
+	}{
+		{name: "Without title", title: false},
+		{name: "With title", title: true, expectedTitle: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := RenderWatchFrame(&sb, tracking, 100, tt.title); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			frame := sb.String()
+
+			if !strings.HasPrefix(frame, cursorHome) || !strings.HasSuffix(frame, clearBelow) {
+				t.Errorf("Expected frame to draw over previous one, got %q", frame)
+			}
+			if hasTitle := strings.Contains(frame, "\x1b]0;Asr in 1h12m\x07"); hasTitle != tt.expectedTitle {
+				t.Errorf("Expected title %v but got frame %q", tt.expectedTitle, frame)
+			}
+			for _, expected := range []string{"Friday 20/03/2026", "Eid al-Fitr", "5 seconds to", "Dhuhr "} {
+				if !strings.Contains(frame, expected) {
+					t.Errorf("Expected frame to contain %q, got %q", expected, frame)
+				}
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(frame, clearBelow), "\n") {
+				if line != "" && !strings.HasSuffix(line, clearLine) {
+					t.Errorf("Expected each line to clear rest of it, got %q", line)
+				}
+			}
+		})
+	}
+}